@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addSpecFlags(checkCmd, "Committed OpenAPI file to check")
+	rootCmd.AddCommand(checkCmd)
+}
+
+var checkCmd = &cobra.Command{
+	Use:          "check",
+	Short:        "Check that the committed spec matches the annotated routes",
+	Long:         "Regenerates the spec in memory with the same flags as generate and compares it with the committed file, ignoring formatting and key order. Exits nonzero and prints a unified diff if they differ.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if output == "-" {
+			return errors.New("check needs the path of the committed spec in --output")
+		}
+
+		spec, err := buildSpec(os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		committed, err := os.ReadFile(output)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%s does not exist, run openapi3gen generate with the same flags", output)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", output, err)
+		}
+
+		// Compare both in the canonical encoding, so formatting and key order do
+		// not count. The committed file is not decoded into the spec types, so
+		// that edits to fields the generator does not write are reported too.
+		if format == "" {
+			format = generator.FormatFromPath(output)
+		}
+		generated, err := generator.MarshalSpec(spec, format)
+		if err != nil {
+			return fmt.Errorf("failed to marshal: %w", err)
+		}
+		want, err := generator.CanonicalSpec(generated, format)
+		if err != nil {
+			return fmt.Errorf("failed to marshal: %w", err)
+		}
+		got, err := generator.CanonicalSpec(committed, format)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", output, err)
+		}
+
+		if bytes.Equal(got, want) {
+			fmt.Printf("✅ %s is up to date\n", output)
+			return nil
+		}
+
+		fmt.Print(unifiedDiff(output+" (committed)", output+" (generated)", splitLines(string(got)), splitLines(string(want)), 3))
+		return fmt.Errorf("%s is out of date, run openapi3gen generate with the same flags", output)
+	},
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
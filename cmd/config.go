@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileNames are the names of the project configuration file, looked up
+// in the project directory and its parents
+var configFileNames = []string{".openapi3gen.yaml", ".openapi3gen.yml"}
+
+// config is the project configuration file. Paths are relative to the directory of the file.
+type config struct {
+	Dir              string                                     `yaml:"-"`        // Project directory: the directory of the file
+	Roots            []string                                   `yaml:"roots"`    // Directories to load
+	Include          []string                                   `yaml:"include"`  // Globs of documented files
+	Exclude          []string                                   `yaml:"exclude"`  // Globs of files neither documented nor searched for models
+	Metadata         string                                     `yaml:"metadata"` // File with the @Global annotations
+	Framework        string                                     `yaml:"framework"`
+	Output           string                                     `yaml:"output"`
+	Format           string                                     `yaml:"format"`
+	OpenAPIVersion   string                                     `yaml:"openapiVersion"`
+	Strict           bool                                       `yaml:"strict"`
+	NullablePointers bool                                       `yaml:"nullablePointers"`
+	Servers          []generator.Server                         `yaml:"servers"`
+	SecuritySchemes  map[string]*generator.SecuritySchemeObject `yaml:"securitySchemes"`
+	DefaultResponses map[string]configResponse                  `yaml:"defaultResponses"` // Keyed by status code
+}
+
+type configResponse struct {
+	Description string `yaml:"description"`
+	Model       string `yaml:"model"`
+	MediaType   string `yaml:"mediaType"`
+}
+
+// projectConfig is the configuration in effect for generate, check and lint: the
+// config file, if any, with the flags given on the command line applied over it
+var projectConfig config
+
+// findConfig returns the config file in dir or the closest of its parents, or "" if there is none
+func findConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// readConfig reads a config file, rejecting unknown keys
+func readConfig(path string) (config, error) {
+	var cfg config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	// Resolve paths against the directory of the config file, so they do not
+	// change meaning when --dir is given
+	base, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Dir = base
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(base, p)
+	}
+	for i, root := range cfg.Roots {
+		cfg.Roots[i] = resolve(root)
+	}
+	for _, globs := range [][]string{cfg.Include, cfg.Exclude} {
+		for i, glob := range globs {
+			globs[i] = filepath.ToSlash(resolve(filepath.FromSlash(glob)))
+		}
+	}
+	cfg.Metadata = resolve(cfg.Metadata)
+	if cfg.Output != "-" {
+		cfg.Output = resolve(cfg.Output)
+	}
+	return cfg, nil
+}
+
+// loadProjectConfig reads the config file given by --config, or found from
+// --dir, and applies it to the flags that were not set on the command line
+func loadProjectConfig(cmd *cobra.Command, args []string) error {
+	path := configPath
+	if path == "" {
+		var err error
+		if path, err = findConfig(dir); err != nil {
+			return err
+		}
+	}
+
+	cfg := config{Dir: dir}
+	if path != "" {
+		var err error
+		if cfg, err = readConfig(path); err != nil {
+			return err
+		}
+	}
+
+	flags := cmd.Flags()
+	override := func(name string, value *string, configured string) {
+		if !flags.Changed(name) && configured != "" {
+			*value = configured
+		}
+	}
+	override("dir", &dir, cfg.Dir)
+	override("output", &output, cfg.Output)
+	override("framework", &framework, cfg.Framework)
+	override("openapi-version", &openapiVersion, cfg.OpenAPIVersion)
+	override("format", &format, cfg.Format)
+	override("metadata", &metadataFile, cfg.Metadata)
+	if !flags.Changed("strict") {
+		strict = cfg.Strict
+	}
+	if !flags.Changed("nullable-pointers") {
+		nullable = cfg.NullablePointers
+	}
+
+	cfg.Dir = dir
+	projectConfig = cfg
+	return nil
+}
+
+// parserOptions returns the parser options of the project
+func (c config) parserOptions() parser.Options {
+	return parser.Options{
+		Framework: framework,
+		Strict:    strict,
+		Roots:     c.Roots,
+		Include:   c.Include,
+		Exclude:   c.Exclude,
+	}
+}
+
+// generatorOptions returns the generator options of the project
+func (c config) generatorOptions() generator.Options {
+	opts := generator.Options{
+		OpenAPIVersion:   openapiVersion,
+		Strict:           strict,
+		Servers:          c.Servers,
+		SecuritySchemes:  c.SecuritySchemes,
+		NullablePointers: nullable,
+	}
+	if len(c.DefaultResponses) > 0 {
+		opts.DefaultResponses = make(map[string]parser.Response, len(c.DefaultResponses))
+		for code, r := range c.DefaultResponses {
+			opts.DefaultResponses[code] = parser.Response{
+				StatusCode:  code,
+				Description: r.Description,
+				Model:       r.Model,
+				MediaType:   r.MediaType,
+			}
+		}
+	}
+	return opts
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat string
+	diffFailOn string
+)
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "Output format: text, json or markdown")
+	diffCmd.Flags().StringVar(&diffFailOn, "fail-on", "breaking", "Exit with an error on breaking changes, any change, or never")
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:          "diff <old-spec> <new-spec>",
+	Short:        "Report breaking and non-breaking changes between two OpenAPI specs",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if diffFailOn != "breaking" && diffFailOn != "any" && diffFailOn != "never" {
+			return fmt.Errorf("unsupported --fail-on %q, expected breaking, any or never", diffFailOn)
+		}
+
+		base, err := generator.ReadSpec(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+		revision, err := generator.ReadSpec(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[1], err)
+		}
+
+		changes := generator.Diff(base, revision)
+
+		switch diffFormat {
+		case "text":
+			writeDiffText(os.Stdout, changes)
+		case "json":
+			if err := writeDiffJSON(os.Stdout, changes); err != nil {
+				return err
+			}
+		case "markdown":
+			writeDiffMarkdown(os.Stdout, changes)
+		default:
+			return fmt.Errorf("unsupported --format %q, expected text, json or markdown", diffFormat)
+		}
+
+		breaking := generator.HasBreaking(changes)
+		switch {
+		case diffFailOn == "breaking" && breaking, diffFailOn == "any" && len(changes) > 0:
+			return fmt.Errorf("found %d change(s), %d breaking", len(changes), countBreaking(changes))
+		}
+		return nil
+	},
+}
+
+func countBreaking(changes []generator.Change) int {
+	n := 0
+	for _, change := range changes {
+		if change.Level == generator.Breaking {
+			n++
+		}
+	}
+	return n
+}
+
+func writeDiffText(w io.Writer, changes []generator.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "✅ No changes")
+		return
+	}
+	for _, change := range changes {
+		level := "   "
+		if change.Level == generator.Breaking {
+			level = "❌ "
+		}
+		fmt.Fprintf(w, "%s[%s] %s: %s (%s)\n", level, change.Kind, change.Operation, change.Message, change.Pointer)
+	}
+	fmt.Fprintf(w, "%d change(s), %d breaking\n", len(changes), countBreaking(changes))
+}
+
+func writeDiffJSON(w io.Writer, changes []generator.Change) error {
+	if changes == nil {
+		changes = []generator.Change{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Breaking bool               `json:"breaking"`
+		Changes  []generator.Change `json:"changes"`
+	}{generator.HasBreaking(changes), changes})
+}
+
+func writeDiffMarkdown(w io.Writer, changes []generator.Change) {
+	fmt.Fprintln(w, "## API changes")
+	fmt.Fprintln(w)
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+
+	fmt.Fprintf(w, "%d change(s), %d breaking.\n\n", len(changes), countBreaking(changes))
+	fmt.Fprintln(w, "| Level | Kind | Operation | Change | Location |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+	for _, change := range changes {
+		level := string(change.Level)
+		if change.Level == generator.Breaking {
+			level = "**breaking**"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s | `%s` |\n", level, change.Kind, escape.Replace(change.Operation), escape.Replace(change.Message), change.Pointer)
+	}
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/georgetjose/openapi3gen/pkg/generator"
 	"github.com/georgetjose/openapi3gen/pkg/parser"
@@ -13,49 +16,158 @@ import (
 )
 
 var (
-	dir    string
-	output string
+	dir            string
+	output         string
+	framework      string
+	openapiVersion string
+	format         string
+	strict         bool
+	nullable       bool
+	configPath     string
+	metadataFile   string
+	watch          bool
 )
 
 func init() {
-	generateCmd.Flags().StringVar(&dir, "dir", ".", "Directory of the Gin project")
-	generateCmd.Flags().StringVar(&output, "output", "./openapi.json", "Output OpenAPI JSON file")
+	addSpecFlags(generateCmd, "Output OpenAPI file, or - for stdout")
+	generateCmd.Flags().BoolVar(&watch, "watch", false, "Regenerate the spec whenever the Go source changes")
 	rootCmd.AddCommand(generateCmd)
 }
 
+// addSpecFlags adds the flags selecting what spec to generate and where it lives
+func addSpecFlags(cmd *cobra.Command, outputUsage string) {
+	cmd.Flags().StringVar(&dir, "dir", ".", "Directory of the Go project")
+	cmd.Flags().StringVar(&output, "output", "./openapi.json", outputUsage)
+	cmd.Flags().StringVar(&framework, "framework", parser.DefaultFramework, "Web framework of the handlers ("+strings.Join(parser.Frameworks(), ", ")+")")
+	cmd.Flags().StringVar(&openapiVersion, "openapi-version", "3.0", "OpenAPI version of the spec (3.0, 3.1)")
+	cmd.Flags().StringVar(&format, "format", "", "Output format (json, yaml); inferred from the output extension by default")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail on warnings such as missing models or mismatched path parameters")
+	cmd.Flags().BoolVar(&nullable, "nullable-pointers", false, "Mark the pointer fields of models nullable")
+	cmd.Flags().StringVar(&metadataFile, "metadata", "main.go", "File with the @Global annotations, relative to --dir")
+	cmd.Flags().StringVar(&configPath, "config", "", "Config file; .openapi3gen.yaml in --dir or a parent directory by default")
+	cmd.PreRunE = loadProjectConfig
+}
+
 var generateCmd = &cobra.Command{
-	Use:   "generate",
-	Short: "Generate OpenAPI 3.0 spec from annotated routes",
+	Use:          "generate",
+	Short:        "Generate OpenAPI 3.0 or 3.1 spec from annotated routes",
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// 1. Parse annotations
-		routes, err := parser.ParseDirectory(dir)
-		if err != nil {
-			return fmt.Errorf("failed to parse: %w", err)
+		// Keep stdout for the spec itself when writing it there
+		status := os.Stdout
+		if output == "-" {
+			status = os.Stderr
 		}
 
-		// 2. Register models
-		registry := generator.NewModelRegistry()
-		// TODO: optionally support JSON schema registry discovery later
-
-		// 3. Generate spec
-		metadata := parser.ParseGlobalMetadata(filepath.Join(dir, "main.go"))
-		spec := generator.GenerateSpec(routes, registry, metadata)
-
-		// 4. Write to output
-		specJson, err := json.MarshalIndent(spec, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal: %w", err)
+		if watch {
+			if output == "-" {
+				return fmt.Errorf("--watch needs an --output file")
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return watchSpec(ctx, status)
 		}
 
-		if err := os.MkdirAll(filepath.Dir(output), os.ModePerm); err != nil {
+		spec, err := buildSpec(status)
+		if err != nil {
 			return err
 		}
+		return writeSpec(status, spec)
+	},
+}
+
+// writeSpec writes spec to the output in the chosen format
+func writeSpec(status io.Writer, spec *generator.OpenAPI) error {
+	if format == "" {
+		format = generator.FormatFromPath(output)
+	}
+	specData, err := generator.MarshalSpec(spec, format)
+	if err != nil {
+		return fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	if output == "-" {
+		_, err := os.Stdout.Write(specData)
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(output), os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(output, specData, 0644); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+
+	fmt.Fprintf(status, "✅ OpenAPI %s spec generated at: %s\n", spec.OpenAPI, output)
+	return nil
+}
+
+// buildSpec parses the project in dir and generates its spec in memory,
+// reporting diagnostics to status
+func buildSpec(status io.Writer) (*generator.OpenAPI, error) {
+	// 1. Parse annotations
+	opts := projectConfig.parserOptions()
+	routes, diags, err := parser.ParseDirectoryWithOptions(dir, opts)
+	printDiagnostics(status, diags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", diagnosticsError(diags, err))
+	}
+
+	// 2. Discover models from source
+	registry, err := generator.NewModelRegistryFromSourceWithOptions(dir, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover models: %w", err)
+	}
+
+	// 3. Generate spec
+	return generateSpec(status, routes, registry)
+}
+
+// globalMetadataPath returns the path of the file with the @Global annotations
+func globalMetadataPath() string {
+	if filepath.IsAbs(metadataFile) {
+		return metadataFile
+	}
+	return filepath.Join(dir, metadataFile)
+}
 
-		if err := os.WriteFile(output, specJson, 0644); err != nil {
-			return fmt.Errorf("failed to write: %w", err)
+// generateSpec generates the spec of routes with the global metadata of the
+// project, reporting diagnostics to status
+func generateSpec(status io.Writer, routes []parser.RouteDoc, registry *generator.ModelRegistry) (*generator.OpenAPI, error) {
+	metadataPath := globalMetadataPath()
+	metadata, err := parser.ReadGlobalMetadata(metadataPath)
+	if err != nil {
+		var metadataDiags parser.Diagnostics
+		metadataDiags.Warnf(parser.CodeMetadataFile, metadataPath, "/info", "global metadata not read: %v", err)
+		if strict {
+			metadataDiags.Strict()
 		}
+		printDiagnostics(status, metadataDiags)
+		if err := metadataDiags.Err(); err != nil {
+			return nil, diagnosticsError(metadataDiags, err)
+		}
+	}
 
-		fmt.Printf("✅ OpenAPI 3.0 spec generated at: %s\n", output)
-		return nil
-	},
+	spec, diags, err := generator.GenerateSpecWithOptions(routes, registry, metadata, projectConfig.generatorOptions())
+	printDiagnostics(status, diags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate: %w", diagnosticsError(diags, err))
+	}
+
+	return spec, nil
+}
+
+func printDiagnostics(w io.Writer, diags parser.Diagnostics) {
+	for _, d := range diags {
+		fmt.Fprintln(w, d)
+	}
+}
+
+// diagnosticsError shortens err when it only repeats the printed diagnostics
+func diagnosticsError(diags parser.Diagnostics, err error) error {
+	if diags.Err() == nil {
+		return err
+	}
+	return fmt.Errorf("%d error(s) in strict mode", len(diags))
 }
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFormat string
+	lintFailOn string
+)
+
+func init() {
+	lintCmd.Flags().StringVar(&dir, "dir", ".", "Directory of the Go project")
+	lintCmd.Flags().StringVar(&configPath, "config", "", "Config file; .openapi3gen.yaml in --dir or a parent directory by default")
+	lintCmd.Flags().StringVar(&lintFormat, "format", "text", "Output format: text, json, sarif or github")
+	lintCmd.Flags().StringVar(&lintFailOn, "fail-on", "error", "Exit with an error on issues of severity error, warning, or never")
+	rootCmd.AddCommand(lintCmd)
+}
+
+var lintCmd = &cobra.Command{
+	Use:          "lint",
+	Short:        "Report malformed annotations without generating a spec",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	PreRunE:      loadProjectConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lintFailOn != "error" && lintFailOn != "warning" && lintFailOn != "never" {
+			return fmt.Errorf("unsupported --fail-on %q, expected error, warning or never", lintFailOn)
+		}
+
+		issues, err := parser.LintWithOptions(dir, projectConfig.parserOptions())
+		if err != nil {
+			return err
+		}
+
+		// Report paths relative to the working directory, as code scanning expects
+		if wd, err := os.Getwd(); err == nil {
+			for i, issue := range issues {
+				if rel, err := filepath.Rel(wd, issue.File); err == nil && !strings.HasPrefix(rel, "..") {
+					issues[i].File = filepath.ToSlash(rel)
+				}
+			}
+		}
+
+		switch lintFormat {
+		case "text":
+			writeLintText(os.Stdout, issues)
+		case "json":
+			if issues == nil {
+				issues = []parser.LintIssue{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(issues); err != nil {
+				return err
+			}
+		case "sarif":
+			if err := writeLintSARIF(os.Stdout, issues); err != nil {
+				return err
+			}
+		case "github":
+			writeLintGitHub(os.Stdout, issues)
+		default:
+			return fmt.Errorf("unsupported --format %q, expected text, json, sarif or github", lintFormat)
+		}
+
+		errors := 0
+		for _, issue := range issues {
+			if issue.Severity == "error" {
+				errors++
+			}
+		}
+		if (lintFailOn == "error" && errors > 0) || (lintFailOn == "warning" && len(issues) > 0) {
+			return fmt.Errorf("found %d issue(s), %d error(s)", len(issues), errors)
+		}
+		return nil
+	},
+}
+
+func writeLintText(w io.Writer, issues []parser.LintIssue) {
+	if len(issues) == 0 {
+		fmt.Fprintln(w, "✅ No annotation issues")
+		return
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s: %s\n", issue.Severity, issue)
+		if issue.Fix != "" {
+			fmt.Fprintf(w, "\tfix: %s\n", issue.Fix)
+		}
+	}
+}
+
+// writeLintGitHub writes issues as GitHub Actions workflow commands, which annotate the PR diff
+func writeLintGitHub(w io.Writer, issues []parser.LintIssue) {
+	escapeData := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	escapeProperty := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+	for _, issue := range issues {
+		message := issue.Message
+		if issue.Fix != "" {
+			message += "\nFix: " + issue.Fix
+		}
+		fmt.Fprintf(w, "::%s file=%s,line=%d,col=%d,title=%s::%s\n", issue.Severity,
+			escapeProperty.Replace(issue.File), issue.Line, issue.Column,
+			escapeProperty.Replace("openapi3gen "+issue.Rule), escapeData.Replace(message))
+	}
+}
+
+// writeLintSARIF writes issues as a SARIF 2.1.0 log for code scanning upload
+func writeLintSARIF(w io.Writer, issues []parser.LintIssue) error {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type rule struct {
+		ID               string  `json:"id"`
+		ShortDescription message `json:"shortDescription"`
+	}
+	type region struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+	type artifactLocation struct {
+		URI string `json:"uri"`
+	}
+	type physicalLocation struct {
+		ArtifactLocation artifactLocation `json:"artifactLocation"`
+		Region           region           `json:"region"`
+	}
+	type location struct {
+		PhysicalLocation physicalLocation `json:"physicalLocation"`
+	}
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations"`
+	}
+
+	var rules []rule
+	for _, id := range slices.Sorted(maps.Keys(parser.LintRules)) {
+		rules = append(rules, rule{ID: id, ShortDescription: message{parser.LintRules[id]}})
+	}
+
+	results := []result{}
+	for _, issue := range issues {
+		text := issue.Message
+		if issue.Fix != "" {
+			text += ". Fix: " + issue.Fix
+		}
+		results = append(results, result{
+			RuleID:  issue.Rule,
+			Level:   issue.Severity,
+			Message: message{text},
+			Locations: []location{{PhysicalLocation: physicalLocation{
+				ArtifactLocation: artifactLocation{URI: issue.File},
+				Region:           region{StartLine: issue.Line, StartColumn: issue.Column},
+			}}},
+		})
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{map[string]any{
+			"tool": map[string]any{"driver": map[string]any{
+				"name":           "openapi3gen",
+				"informationUri": "https://github.com/georgetjose/openapi3gen",
+				"rules":          rules,
+			}},
+			"results": results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -10,11 +10,13 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "openapi3gen",
 	Short: "Generate OpenAPI 3.0 spec from annotated Gin handlers",
+	// Execute prints the error itself
+	SilenceErrors: true,
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
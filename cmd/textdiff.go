@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxEditDistance bounds the work of diffLines; larger differences are shown
+// as a replacement of everything between the common prefix and suffix
+const maxEditDistance = 2000
+
+// diffOp is a line of an edit script: kept (' '), deleted ('-') or inserted ('+')
+type diffOp struct {
+	kind byte
+	line string
+	a, b int // Index of the line in a and b; for insertions a is where it goes, for deletions b
+}
+
+// unifiedDiff renders the difference between the lines of a and b as a
+// unified diff with context lines around every change
+func unifiedDiff(fromName, toName string, a, b []string, context int) string {
+	ops := diffLines(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk while changes are within 2*context lines of each other
+		start := max(i-context, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*context {
+				end = min(end+context, len(ops))
+				break
+			}
+			end = next
+		}
+
+		hunk := ops[start:end]
+		var aLen, bLen int
+		for _, op := range hunk {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+		}
+		aStart, bStart := hunk[0].a+1, hunk[0].b+1
+		if aLen == 0 {
+			aStart--
+		}
+		if bLen == 0 {
+			bStart--
+		}
+
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+		for _, op := range hunk {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.line)
+			sb.WriteByte('\n')
+		}
+		i = end
+	}
+
+	return sb.String()
+}
+
+// diffLines returns a shortest edit script turning a into b, using the linear
+// space variant of Myers' algorithm: the middle of an optimal path is found
+// by searching from both ends at once, and both halves are diffed recursively
+func diffLines(a, b []string) []diffOp {
+	var d lineDiffer
+	d.diff(a, b, 0, 0)
+	return d.ops
+}
+
+// lineDiffer collects the edit script of diffLines
+type lineDiffer struct {
+	ops []diffOp
+}
+
+// diff appends the edit script turning a into b, which start at line aOff
+// and bOff of the compared texts
+func (d *lineDiffer) diff(a, b []string, aOff, bOff int) {
+	// Common prefix and suffix are kept as they are
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		d.ops = append(d.ops, diffOp{' ', a[prefix], aOff + prefix, bOff + prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	d.diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], aOff+prefix, bOff+prefix)
+
+	for i := suffix; i > 0; i-- {
+		d.ops = append(d.ops, diffOp{' ', a[len(a)-i], aOff + len(a) - i, bOff + len(b) - i})
+	}
+}
+
+// diffMiddle appends the edit script turning a into b, which share neither
+// their first nor their last line
+func (d *lineDiffer) diffMiddle(a, b []string, aOff, bOff int) {
+	if len(a) > 0 && len(b) > 0 {
+		if x, y, ok := bisect(a, b); ok {
+			d.diff(a[:x], b[:y], aOff, bOff)
+			d.diff(a[x:], b[y:], aOff+x, bOff+y)
+			return
+		}
+	}
+
+	for i, line := range a {
+		d.ops = append(d.ops, diffOp{'-', line, aOff + i, bOff})
+	}
+	for i, line := range b {
+		d.ops = append(d.ops, diffOp{'+', line, aOff + len(a), bOff + i})
+	}
+}
+
+// bisect returns a point (x, y) on a shortest edit path from a to b, where
+// the paths searched forward from the start and backward from the end meet.
+// It reports false if the edit distance exceeds maxEditDistance.
+func bisect(a, b []string) (x, y int, ok bool) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	// Furthest x reached on each diagonal k = x-y, forward from the start and,
+	// counting from the end, backward
+	forward := make([]int, 2*maxD+2)
+	backward := make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	// With an odd delta the paths meet in a forward round, with an even one in a backward round
+	delta := n - m
+	odd := delta%2 != 0
+	// Diagonals are trimmed once their paths leave the edit graph
+	var fStart, fEnd, bStart, bEnd int
+	for d := 0; d < min(maxD, maxEditDistance/2+1); d++ {
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case odd:
+				if bk := offset + delta - k; bk >= 0 && bk < len(backward) && backward[bk] != -1 && x >= n-backward[bk] {
+					return x, y, true
+				}
+			}
+		}
+
+		for k := -d + bStart; k <= d-bEnd; k += 2 {
+			var x int
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !odd:
+				if fk := offset + delta - k; fk >= 0 && fk < len(forward) && forward[fk] != -1 && forward[fk] >= n-x {
+					fx := forward[fk]
+					return fx, fx - (fk - offset), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
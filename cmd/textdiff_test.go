@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "Equal",
+			a:    "a b c",
+			b:    "a b c",
+			want: "",
+		},
+		{
+			name: "Changed",
+			a:    "1 2 3 4 5 6 7 8 9",
+			b:    "1 2 3 4 X 6 7 8 9",
+			want: "@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+X\n 6\n 7\n 8\n",
+		},
+		{
+			name: "AtStart",
+			a:    "1 2 3 4 5 6",
+			b:    "X 2 3 4 5 6",
+			want: "@@ -1,4 +1,4 @@\n-1\n+X\n 2\n 3\n 4\n",
+		},
+		{
+			name: "AtEnd",
+			a:    "1 2 3 4 5 6",
+			b:    "1 2 3 4 5",
+			want: "@@ -3,4 +3,3 @@\n 3\n 4\n 5\n-6\n",
+		},
+		{
+			name: "Inserted",
+			a:    "1 2 3 4 5 6",
+			b:    "1 2 3 X 4 5 6",
+			want: "@@ -1,6 +1,7 @@\n 1\n 2\n 3\n+X\n 4\n 5\n 6\n",
+		},
+		{
+			name: "IntoEmpty",
+			a:    "",
+			b:    "1 2",
+			want: "@@ -0,0 +1,2 @@\n+1\n+2\n",
+		},
+		{
+			name: "AllRemoved",
+			a:    "1 2",
+			b:    "",
+			want: "@@ -1,2 +0,0 @@\n-1\n-2\n",
+		},
+		{
+			name: "ContextMerged",
+			a:    "1 2 3 4 5 6 7 8 9 10",
+			b:    "1 X 3 4 5 6 7 Y 9 10",
+			want: "@@ -1,10 +1,10 @@\n 1\n-2\n+X\n 3\n 4\n 5\n 6\n 7\n-8\n+Y\n 9\n 10\n",
+		},
+		{
+			name: "ContextTouching",
+			a:    "1 2 3 4 5 6 7 8 9 10 11",
+			b:    "X 2 3 4 5 6 7 Y 9 10 11",
+			want: "@@ -1,11 +1,11 @@\n-1\n+X\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+Y\n 9\n 10\n 11\n",
+		},
+		{
+			name: "SeparateHunks",
+			a:    "1 2 3 4 5 6 7 8 9 10 11 12",
+			b:    "X 2 3 4 5 6 7 8 Y 10 11 12",
+			want: "@@ -1,4 +1,4 @@\n-1\n+X\n 2\n 3\n 4\n@@ -6,7 +6,7 @@\n 6\n 7\n 8\n-9\n+Y\n 10\n 11\n 12\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unifiedDiff("a", "b", strings.Fields(tt.a), strings.Fields(tt.b), 3)
+			want := "--- a\n+++ b\n" + tt.want
+			if got != want {
+				t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesShortest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rng.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		ops := diffLines(a, b)
+
+		var gotA, gotB []string
+		edits := 0
+		for _, op := range ops {
+			if op.kind != '+' {
+				if op.a != len(gotA) {
+					t.Fatalf("diffLines(%q, %q): line %q at a index %d, want %d", a, b, op.line, op.a, len(gotA))
+				}
+				gotA = append(gotA, op.line)
+			}
+			if op.kind != '-' {
+				if op.b != len(gotB) {
+					t.Fatalf("diffLines(%q, %q): line %q at b index %d, want %d", a, b, op.line, op.b, len(gotB))
+				}
+				gotB = append(gotB, op.line)
+			}
+			if op.kind != ' ' {
+				edits++
+			}
+		}
+		if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+			t.Fatalf("diffLines(%q, %q) does not turn a into b: %v", a, b, ops)
+		}
+		if want := editDistance(a, b); edits != want {
+			t.Fatalf("diffLines(%q, %q) has %d edits, want %d", a, b, edits, want)
+		}
+	}
+}
+
+// editDistance returns the number of insertions and deletions turning a into b
+func editDistance(a, b []string) int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	return len(a) + len(b) - 2*lcs[0][0]
+}
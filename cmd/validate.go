@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+var validateCmd = &cobra.Command{
+	Use:          "validate <spec>...",
+	Short:        "Validate OpenAPI 3.0/3.1 JSON or YAML spec files",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problems := 0
+		for _, path := range args {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			spec, errs, err := generator.ValidateSpec(data, generator.FormatFromPath(path))
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			for _, e := range errs {
+				fmt.Printf("%s#%s: %s\n", path, e.Pointer, e.Message)
+			}
+			problems += len(errs)
+
+			if len(errs) == 0 {
+				fmt.Printf("✅ %s is a valid OpenAPI %s spec\n", path, spec.OpenAPI)
+			}
+		}
+
+		if problems > 0 {
+			return fmt.Errorf("found %d problem(s)", problems)
+		}
+		return nil
+	},
+}
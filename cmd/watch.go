@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long watch mode waits for changes to settle before
+// regenerating, since editors and tools often write several files at once
+const watchDebounce = 300 * time.Millisecond
+
+// watchSpec generates the spec, then regenerates it whenever the Go source
+// of the project changes, until ctx is done. Only the packages affected by a
+// change are loaded again. Generation errors are reported to status and the
+// spec is kept as it was.
+func watchSpec(ctx context.Context, status io.Writer) error {
+	project, err := parser.NewProject(dir, projectConfig.parserOptions())
+	if err != nil {
+		return err
+	}
+	if err := project.Load(); err != nil {
+		return err
+	}
+	regenerate := func() {
+		if err := writeProjectSpec(status, project); err != nil {
+			fmt.Fprintf(status, "❌ %v\n", err)
+		}
+	}
+	regenerate()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch: %w", err)
+	}
+	defer watcher.Close()
+
+	metadataPath, err := filepath.Abs(globalMetadataPath())
+	if err != nil {
+		return err
+	}
+	addWatches := func() error {
+		dirs, err := project.WatchDirs()
+		if err != nil {
+			return err
+		}
+		// The metadata file may be outside the packages of the roots
+		for _, dir := range append(dirs, filepath.Dir(metadataPath)) {
+			if err := watcher.Add(dir); err != nil {
+				return fmt.Errorf("failed to watch %s: %w", dir, err)
+			}
+		}
+		return nil
+	}
+	if err := addWatches(); err != nil {
+		return err
+	}
+	outputPath, err := filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(status, "👀 Watching %s for changes, press Ctrl+C to stop\n", dir)
+
+	changed := make(map[string]bool)
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod || event.Name == outputPath {
+				continue
+			}
+			changed[event.Name] = true
+			debounce.Reset(watchDebounce)
+
+			// Watch new directories right away, even while they hold no package
+			// yet, so that the files later written into them are seen
+			if event.Has(fsnotify.Create) && isDir(event.Name) {
+				if err := addWatches(); err != nil {
+					fmt.Fprintf(status, "❌ %v\n", err)
+				}
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(status, "❌ watch: %v\n", err)
+
+		case <-debounce.C:
+			paths := slices.Sorted(maps.Keys(changed))
+			clear(changed)
+
+			reloaded, err := project.Reload(paths)
+			if err != nil {
+				fmt.Fprintf(status, "❌ %v\n", err)
+				continue
+			}
+			if len(reloaded) == 0 && !slices.Contains(paths, metadataPath) {
+				continue
+			}
+
+			fmt.Fprintf(status, "🔄 %s: %s changed, reloaded %d package(s)\n",
+				time.Now().Format(time.TimeOnly), describeChanges(paths), len(reloaded))
+			regenerate()
+
+			// Pick up the directories of new packages
+			if err := addWatches(); err != nil {
+				fmt.Fprintf(status, "❌ %v\n", err)
+			}
+		}
+	}
+}
+
+// writeProjectSpec generates the spec of the loaded packages of project and writes it
+func writeProjectSpec(status io.Writer, project *parser.Project) error {
+	routes, diags, err := project.Parse()
+	printDiagnostics(status, diags)
+	if err != nil {
+		return fmt.Errorf("failed to parse: %w", diagnosticsError(diags, err))
+	}
+
+	spec, err := generateSpec(status, routes, generator.NewModelRegistryFromTypes(project.Models()))
+	if err != nil {
+		return err
+	}
+	return writeSpec(status, spec)
+}
+
+// describeChanges names the changed paths, relative to dir when possible
+func describeChanges(paths []string) string {
+	base, _ := filepath.Abs(dir)
+	var names []string
+	for _, path := range paths {
+		if rel, err := filepath.Rel(base, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		names = append(names, path)
+	}
+	if len(names) > 3 {
+		return fmt.Sprintf("%s and %d more", strings.Join(names[:3], ", "), len(names)-3)
+	}
+	return strings.Join(names, ", ")
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
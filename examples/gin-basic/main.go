@@ -146,7 +146,10 @@ func main() {
 	r := gin.Default()
 
 	// Parse annotations
-	routes, err := parser.ParseDirectory("./")
+	routes, diags, err := parser.ParseDirectoryWithOptions("./", parser.Options{})
+	for _, d := range diags {
+		log.Println(d)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -181,5 +184,12 @@ func main() {
 
 	r.GET("/user/searchauto", SearchUserHandlerAuto)
 
+	// Warn about routes missing from the spec and operations that are never registered
+	report, err := ui.ReconcileRoutes(r, openapi, ui.ReconcileOptions{Routes: routes})
+	if err != nil {
+		log.Fatal(err)
+	}
+	ui.RegisterRouteReportHandler(r, report)
+
 	r.Run(":8081")
 }
@@ -0,0 +1,530 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ChangeLevel tells whether a change can break existing clients
+type ChangeLevel string
+
+const (
+	Breaking    ChangeLevel = "breaking"
+	NonBreaking ChangeLevel = "non-breaking"
+)
+
+// Change kinds reported by Diff
+const (
+	ChangeOperationRemoved    = "operation-removed"
+	ChangeOperationAdded      = "operation-added"
+	ChangeOperationDeprecated = "operation-deprecated"
+	ChangeParameterRemoved    = "parameter-removed"
+	ChangeParameterAdded      = "parameter-added"
+	ChangeParameterRequired   = "parameter-required"
+	ChangeRequestBodyAdded    = "request-body-added"
+	ChangeRequestBodyRemoved  = "request-body-removed"
+	ChangeRequestBodyRequired = "request-body-required"
+	ChangeMediaTypeRemoved    = "media-type-removed"
+	ChangeMediaTypeAdded      = "media-type-added"
+	ChangeResponseRemoved     = "response-removed"
+	ChangeResponseAdded       = "response-added"
+	ChangePropertyRemoved     = "property-removed"
+	ChangePropertyAdded       = "property-added"
+	ChangePropertyRequired    = "property-required"
+	ChangePropertyOptional    = "property-optional"
+	ChangeTypeChanged         = "type-changed"
+	ChangeEnumNarrowed        = "enum-narrowed"
+	ChangeEnumWidened         = "enum-widened"
+)
+
+// Change is a difference between two specs that matters to clients
+type Change struct {
+	Level     ChangeLevel `json:"level"`
+	Kind      string      `json:"kind"`                // One of the Change* kinds
+	Operation string      `json:"operation,omitempty"` // e.g. GET /users/{id}
+	Pointer   string      `json:"pointer"`             // Into the new spec, or into the old one for removals
+	Message   string      `json:"message"`
+}
+
+// HasBreaking reports whether any of changes is breaking
+func HasBreaking(changes []Change) bool {
+	for _, change := range changes {
+		if change.Level == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff compares the operations of two specs and classifies what changed for
+// clients of the API. Operations are matched by method and path, ignoring the
+// names of path parameters. Removed operations, response codes and response
+// properties, newly required parameters and body fields, changed types and
+// enums accepting fewer values are breaking. Webhooks are matched by name and
+// method; since the API sends their requests and clients respond, the rules for
+// requests and responses are swapped for them. Changes are returned in document order.
+func Diff(base, revision *OpenAPI) []Change {
+	d := &differ{base: base, revision: revision}
+	d.diff()
+	return d.changes
+}
+
+// direction tells whether a schema describes data sent by clients or returned to them
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+type differ struct {
+	base, revision *OpenAPI
+	section        string // "/paths" or "/webhooks"
+	operation      string // Operation being compared, e.g. GET /users/{id}
+	changes        []Change
+}
+
+// requestDirection returns the direction of the requests of the section being
+// compared: webhook requests are sent to clients
+func (d *differ) requestDirection() direction {
+	if d.section == "/webhooks" {
+		return response
+	}
+	return request
+}
+
+// responseDirection returns the direction of the responses of the section being compared
+func (d *differ) responseDirection() direction {
+	if d.section == "/webhooks" {
+		return request
+	}
+	return response
+}
+
+func (d *differ) report(level ChangeLevel, kind, ptr, format string, args ...any) {
+	d.changes = append(d.changes, Change{
+		Level:     level,
+		Kind:      kind,
+		Operation: d.operation,
+		Pointer:   ptr,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *differ) diff() {
+	d.section = "/paths"
+	d.diffPathItems(d.base.Paths, d.revision.Paths, pathsByTemplate(d.base.Paths), pathsByTemplate(d.revision.Paths))
+
+	d.section = "/webhooks"
+	d.diffPathItems(d.base.Webhooks, d.revision.Webhooks, webhooksByName(d.base.Webhooks), webhooksByName(d.revision.Webhooks))
+
+	d.section, d.operation = "", ""
+}
+
+// diffPathItems compares the operations of the path items of the section being
+// compared, matched by the keys of baseKeys and revisionKeys
+func (d *differ) diffPathItems(baseItems, revisionItems map[string]*PathItem, baseKeys, revisionKeys map[string]string) {
+	keys := slices.Collect(maps.Keys(baseKeys))
+	for key := range revisionKeys {
+		if _, ok := baseKeys[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		basePath, revisionPath := baseKeys[key], revisionKeys[key]
+		var baseItem, revisionItem *PathItem
+		if basePath != "" {
+			baseItem = baseItems[basePath]
+		}
+		if revisionPath != "" {
+			revisionItem = revisionItems[revisionPath]
+		}
+
+		for _, method := range HTTPMethods {
+			var baseOp, revisionOp *Operation
+			if baseItem != nil {
+				baseOp = *baseItem.operation(method)
+			}
+			if revisionItem != nil {
+				revisionOp = *revisionItem.operation(method)
+			}
+
+			switch {
+			case baseOp == nil && revisionOp == nil:
+				continue
+			case revisionOp == nil:
+				d.operation = d.operationName(method, basePath)
+				d.report(Breaking, ChangeOperationRemoved, pointer(d.section, basePath, method), "operation was removed")
+			case baseOp == nil:
+				d.operation = d.operationName(method, revisionPath)
+				d.report(NonBreaking, ChangeOperationAdded, pointer(d.section, revisionPath, method), "operation was added")
+			default:
+				d.operation = d.operationName(method, revisionPath)
+				d.diffOperation(basePath, revisionPath, method, baseItem, revisionItem)
+			}
+		}
+	}
+}
+
+// operationName names an operation in the changes, e.g. GET /users/{id} or POST webhook newUser
+func (d *differ) operationName(method, path string) string {
+	if d.section == "/webhooks" {
+		return strings.ToUpper(method) + " webhook " + path
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+// webhooksByName keys webhooks by their name, as pathsByTemplate keys paths
+func webhooksByName(webhooks map[string]*PathItem) map[string]string {
+	names := make(map[string]string)
+	for name, item := range webhooks {
+		if item != nil {
+			names[name] = name
+		}
+	}
+	return names
+}
+
+// pathsByTemplate keys paths by their template with the parameter names removed,
+// so /users/{id} and /users/{userId} are the same path
+func pathsByTemplate(paths map[string]*PathItem) map[string]string {
+	templates := make(map[string]string)
+	for path, item := range paths {
+		if item != nil {
+			templates[pathTemplatePattern.ReplaceAllString(path, "{}")] = path
+		}
+	}
+	return templates
+}
+
+func (d *differ) diffOperation(basePath, revisionPath, method string, baseItem, revisionItem *PathItem) {
+	baseOp, revisionOp := *baseItem.operation(method), *revisionItem.operation(method)
+	basePtr, revisionPtr := pointer(d.section, basePath, method), pointer(d.section, revisionPath, method)
+
+	if revisionOp.Deprecated && !baseOp.Deprecated {
+		d.report(NonBreaking, ChangeOperationDeprecated, pointer(revisionPtr, "deprecated"), "operation was deprecated")
+	}
+
+	d.diffParameters(
+		operationParameters(d.base, d.section, basePtr, basePath, baseItem, baseOp),
+		operationParameters(d.revision, d.section, revisionPtr, revisionPath, revisionItem, revisionOp),
+	)
+	d.diffRequestBody(pointer(basePtr, "requestBody"), pointer(revisionPtr, "requestBody"), baseOp.RequestBody, revisionOp.RequestBody)
+
+	for _, code := range slices.Sorted(maps.Keys(baseOp.Responses)) {
+		baseResp := resolveResponse(d.base, baseOp.Responses[code])
+		revisionResp, ok := revisionOp.Responses[code]
+		if !ok {
+			d.report(Breaking, ChangeResponseRemoved, pointer(basePtr, "responses", code), "response %s was removed", code)
+			continue
+		}
+		revisionResp = resolveResponse(d.revision, revisionResp)
+		if baseResp == nil || revisionResp == nil {
+			continue
+		}
+		d.diffContent(pointer(basePtr, "responses", code), pointer(revisionPtr, "responses", code), baseResp.Content, revisionResp.Content, d.responseDirection())
+	}
+	for _, code := range slices.Sorted(maps.Keys(revisionOp.Responses)) {
+		if _, ok := baseOp.Responses[code]; !ok {
+			d.report(NonBreaking, ChangeResponseAdded, pointer(revisionPtr, "responses", code), "response %s was added", code)
+		}
+	}
+}
+
+// operationParameter is a parameter in effect for an operation, with its pointer
+type operationParameter struct {
+	ptr   string
+	param *ParameterObject
+}
+
+// operationParameters returns the parameters of op, including those shared by its
+// path item, keyed by location and name. Path parameters are keyed by their
+// position in the path since renaming them does not affect clients.
+func operationParameters(openapi *OpenAPI, section, opPtr, path string, item *PathItem, op *Operation) map[string]operationParameter {
+	params := make(map[string]operationParameter)
+	names := pathTemplateNames(path)
+
+	add := func(ptr string, parameters []*ParameterObject) {
+		for i, param := range parameters {
+			param = resolveParameter(openapi, param)
+			if param == nil {
+				continue
+			}
+			key := param.In + ":" + param.Name
+			if param.In == "path" {
+				if index := slices.Index(names, param.Name); index >= 0 {
+					key = fmt.Sprintf("path:%d", index)
+				}
+			}
+			params[key] = operationParameter{ptr: pointer(ptr, "parameters", i), param: param}
+		}
+	}
+	add(pointer(section, path), item.Parameters)
+	add(opPtr, op.Parameters)
+	return params
+}
+
+// diffParameters compares the parameters of an operation. Webhook parameters
+// are sent to clients, so removing them is breaking and requiring them is not.
+func (d *differ) diffParameters(base, revision map[string]operationParameter) {
+	dir := d.requestDirection()
+	removedLevel := NonBreaking
+	if dir == response {
+		removedLevel = Breaking
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(base)) {
+		baseParam := base[key]
+		revisionParam, ok := revision[key]
+		if !ok {
+			d.report(removedLevel, ChangeParameterRemoved, baseParam.ptr, "%s parameter %q was removed", baseParam.param.In, baseParam.param.Name)
+			continue
+		}
+
+		if dir == request && revisionParam.param.Required && !baseParam.param.Required {
+			d.report(Breaking, ChangeParameterRequired, pointer(revisionParam.ptr, "required"), "%s parameter %q became required", revisionParam.param.In, revisionParam.param.Name)
+		}
+		d.diffSchema(pointer(baseParam.ptr, "schema"), pointer(revisionParam.ptr, "schema"), baseParam.param.Schema, revisionParam.param.Schema, dir, nil)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(revision)) {
+		if _, ok := base[key]; ok {
+			continue
+		}
+		revisionParam := revision[key]
+		if dir == request && revisionParam.param.Required {
+			d.report(Breaking, ChangeParameterRequired, revisionParam.ptr, "required %s parameter %q was added", revisionParam.param.In, revisionParam.param.Name)
+		} else {
+			d.report(NonBreaking, ChangeParameterAdded, revisionParam.ptr, "optional %s parameter %q was added", revisionParam.param.In, revisionParam.param.Name)
+		}
+	}
+}
+
+// diffRequestBody compares the request bodies of an operation. Webhook request
+// bodies are sent to clients, so removing them is breaking and requiring them is not.
+func (d *differ) diffRequestBody(basePtr, revisionPtr string, base, revision *RequestBodyObject) {
+	base, revision = resolveRequestBody(d.base, base), resolveRequestBody(d.revision, revision)
+	dir := d.requestDirection()
+
+	switch {
+	case base == nil && revision == nil:
+	case revision == nil:
+		if dir == response {
+			d.report(Breaking, ChangeRequestBodyRemoved, basePtr, "request body was removed")
+		} else {
+			d.report(NonBreaking, ChangeRequestBodyRemoved, basePtr, "request body was removed")
+		}
+	case base == nil:
+		if dir == request && revision.Required {
+			d.report(Breaking, ChangeRequestBodyRequired, revisionPtr, "required request body was added")
+		} else {
+			d.report(NonBreaking, ChangeRequestBodyAdded, revisionPtr, "optional request body was added")
+		}
+	default:
+		if dir == request && revision.Required && !base.Required {
+			d.report(Breaking, ChangeRequestBodyRequired, pointer(revisionPtr, "required"), "request body became required")
+		}
+		d.diffContent(basePtr, revisionPtr, base.Content, revision.Content, dir)
+	}
+}
+
+func (d *differ) diffContent(basePtr, revisionPtr string, base, revision map[string]MediaType, dir direction) {
+	for _, mediaType := range slices.Sorted(maps.Keys(base)) {
+		revisionMedia, ok := revision[mediaType]
+		if !ok {
+			d.report(Breaking, ChangeMediaTypeRemoved, pointer(basePtr, "content", mediaType), "media type %s was removed", mediaType)
+			continue
+		}
+		d.diffSchema(pointer(basePtr, "content", mediaType, "schema"), pointer(revisionPtr, "content", mediaType, "schema"), base[mediaType].Schema, revisionMedia.Schema, dir, nil)
+	}
+	for _, mediaType := range slices.Sorted(maps.Keys(revision)) {
+		if _, ok := base[mediaType]; !ok {
+			d.report(NonBreaking, ChangeMediaTypeAdded, pointer(revisionPtr, "content", mediaType), "media type %s was added", mediaType)
+		}
+	}
+}
+
+// diffSchema compares two schemas of data flowing in dir. seen holds the pairs
+// of $refs being compared, which stops recursive schemas.
+func (d *differ) diffSchema(basePtr, revisionPtr string, base, revision *Schema, dir direction, seen map[string]bool) {
+	if base == nil || revision == nil {
+		return
+	}
+	if base.Ref != "" || revision.Ref != "" {
+		key := base.Ref + " " + revision.Ref
+		if seen[key] {
+			return
+		}
+		seen = maps.Clone(seen)
+		if seen == nil {
+			seen = make(map[string]bool)
+		}
+		seen[key] = true
+
+		// Report changes at the referenced schema, which is where they are made
+		if base.Ref != "" {
+			basePtr = strings.TrimPrefix(base.Ref, "#")
+		}
+		if revision.Ref != "" {
+			revisionPtr = strings.TrimPrefix(revision.Ref, "#")
+		}
+		base, revision = resolveSchema(d.base, base), resolveSchema(d.revision, revision)
+		if base == nil || revision == nil {
+			return
+		}
+	}
+
+	if baseType, revisionType := schemaType(base), schemaType(revision); baseType != "" && revisionType != "" && baseType != revisionType {
+		d.report(Breaking, ChangeTypeChanged, pointer(revisionPtr, "type"), "type changed from %s to %s", baseType, revisionType)
+		return
+	}
+
+	d.diffEnum(revisionPtr, base.Enum, revision.Enum, dir)
+
+	for _, name := range slices.Sorted(maps.Keys(base.Properties)) {
+		baseRequired, revisionRequired := slices.Contains(base.Required, name), slices.Contains(revision.Required, name)
+		revisionProp, ok := revision.Properties[name]
+		if !ok {
+			if dir == response {
+				d.report(Breaking, ChangePropertyRemoved, pointer(basePtr, "properties", name), "response property %q was removed", name)
+			} else {
+				d.report(NonBreaking, ChangePropertyRemoved, pointer(basePtr, "properties", name), "request property %q was removed", name)
+			}
+			continue
+		}
+
+		switch {
+		case dir == request && revisionRequired && !baseRequired:
+			d.report(Breaking, ChangePropertyRequired, pointer(revisionPtr, "required"), "request property %q became required", name)
+		case dir == response && baseRequired && !revisionRequired:
+			d.report(Breaking, ChangePropertyOptional, pointer(revisionPtr, "required"), "response property %q became optional", name)
+		}
+		d.diffSchema(pointer(basePtr, "properties", name), pointer(revisionPtr, "properties", name), base.Properties[name], revisionProp, dir, seen)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(revision.Properties)) {
+		if _, ok := base.Properties[name]; ok {
+			continue
+		}
+		if dir == request && slices.Contains(revision.Required, name) {
+			d.report(Breaking, ChangePropertyRequired, pointer(revisionPtr, "properties", name), "required request property %q was added", name)
+		} else {
+			d.report(NonBreaking, ChangePropertyAdded, pointer(revisionPtr, "properties", name), "property %q was added", name)
+		}
+	}
+
+	d.diffSchema(pointer(basePtr, "items"), pointer(revisionPtr, "items"), base.Items, revision.Items, dir, seen)
+}
+
+// diffEnum reports enums that no longer accept values clients send, or that
+// return values clients have not seen before
+func (d *differ) diffEnum(ptr string, base, revision []any, dir direction) {
+	narrowedLevel, widenedLevel := Breaking, NonBreaking
+	if dir == response {
+		narrowedLevel, widenedLevel = NonBreaking, Breaking
+	}
+
+	switch {
+	case len(base) == 0 && len(revision) == 0:
+	case len(base) == 0:
+		d.report(narrowedLevel, ChangeEnumNarrowed, pointer(ptr, "enum"), "enum %s was added", formatEnum(revision))
+	case len(revision) == 0:
+		d.report(widenedLevel, ChangeEnumWidened, pointer(ptr, "enum"), "enum %s was removed", formatEnum(base))
+	default:
+		if removed := enumDifference(base, revision); len(removed) > 0 {
+			d.report(narrowedLevel, ChangeEnumNarrowed, pointer(ptr, "enum"), "enum values %s were removed", formatEnum(removed))
+		}
+		if added := enumDifference(revision, base); len(added) > 0 {
+			d.report(widenedLevel, ChangeEnumWidened, pointer(ptr, "enum"), "enum values %s were added", formatEnum(added))
+		}
+	}
+}
+
+// enumDifference returns the values of a that are not in b
+func enumDifference(a, b []any) []any {
+	var difference []any
+	for _, value := range a {
+		if !slices.ContainsFunc(b, func(v any) bool { return fmt.Sprint(v) == fmt.Sprint(value) }) {
+			difference = append(difference, value)
+		}
+	}
+	return difference
+}
+
+func formatEnum(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = fmt.Sprintf("%v", value)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+// schemaType returns the type of s, ignoring nullability
+func schemaType(s *Schema) string {
+	if s.Type != "" {
+		return s.Type
+	}
+	var types []string
+	for _, t := range s.Types {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	return strings.Join(types, ",")
+}
+
+// componentName returns the name of the component a local $ref such as
+// #/components/schemas/User points to in section
+func componentName(ref, section string) (string, bool) {
+	name, ok := strings.CutPrefix(ref, "#/components/"+section+"/")
+	return unescapePointerToken(name), ok
+}
+
+func resolveSchema(openapi *OpenAPI, s *Schema) *Schema {
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	name, ok := componentName(s.Ref, "schemas")
+	if !ok || openapi.Components == nil {
+		return nil
+	}
+	return openapi.Components.Schemas[name]
+}
+
+// resolveParameter follows a parameter $ref into the components, returning nil if it does not resolve
+func resolveParameter(openapi *OpenAPI, param *ParameterObject) *ParameterObject {
+	if param == nil || param.Ref == "" {
+		return param
+	}
+	name, ok := componentName(param.Ref, "parameters")
+	if !ok || openapi.Components == nil {
+		return nil
+	}
+	return openapi.Components.Parameters[name]
+}
+
+func resolveRequestBody(openapi *OpenAPI, body *RequestBodyObject) *RequestBodyObject {
+	if body == nil || body.Ref == "" {
+		return body
+	}
+	name, ok := componentName(body.Ref, "requestBodies")
+	if !ok || openapi.Components == nil {
+		return nil
+	}
+	return openapi.Components.RequestBodies[name]
+}
+
+func resolveResponse(openapi *OpenAPI, resp *ResponseWrapper) *ResponseWrapper {
+	if resp == nil || resp.Ref == "" {
+		return resp
+	}
+	name, ok := componentName(resp.Ref, "responses")
+	if !ok || openapi.Components == nil {
+		return nil
+	}
+	return openapi.Components.Responses[name]
+}
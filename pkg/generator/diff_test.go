@@ -0,0 +1,306 @@
+package generator
+
+import (
+	"slices"
+	"testing"
+)
+
+// diffSpec returns the spec the revisions in TestDiff are made to
+func diffSpec() *OpenAPI {
+	jsonContent := func(ref string) map[string]MediaType {
+		return map[string]MediaType{"application/json": {Schema: &Schema{Ref: "#/components/schemas/" + ref}}}
+	}
+
+	return &OpenAPI{
+		OpenAPI: OpenAPIVersion31,
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Get: &Operation{
+					Parameters: []*ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+						{Name: "q", In: "query", Schema: &Schema{Type: "string"}},
+					},
+					Responses: map[string]*ResponseWrapper{
+						"200": {Description: "OK", Content: jsonContent("User")},
+					},
+				},
+			},
+			"/users": {
+				Post: &Operation{
+					RequestBody: &RequestBodyObject{Content: jsonContent("NewUser")},
+					Responses: map[string]*ResponseWrapper{
+						"201": {Description: "Created"},
+					},
+				},
+			},
+		},
+		Webhooks: map[string]*PathItem{
+			"userCreated": {
+				Post: &Operation{
+					RequestBody: &RequestBodyObject{Content: jsonContent("Event")},
+					Responses: map[string]*ResponseWrapper{
+						"200": {Description: "OK"},
+					},
+				},
+			},
+		},
+		Components: &Components{
+			Schemas: map[string]*Schema{
+				"User": {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"id":     {Type: "string"},
+						"status": {Type: "string", Enum: []any{"active", "inactive"}},
+					},
+					Required: []string{"id"},
+				},
+				"NewUser": {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"name": {Type: "string"},
+						"role": {Type: "string", Enum: []any{"admin", "member"}},
+					},
+				},
+				"Event": {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"id": {Type: "string"},
+					},
+					Required: []string{"id"},
+				},
+			},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		revise func(spec *OpenAPI)
+		want   []string // level, kind and operation of each change
+	}{
+		{
+			name:   "NoChanges",
+			revise: func(spec *OpenAPI) {},
+		},
+		{
+			name: "PathParameterRenamed",
+			revise: func(spec *OpenAPI) {
+				item := spec.Paths["/users/{id}"]
+				item.Get.Parameters[0].Name = "userId"
+				delete(spec.Paths, "/users/{id}")
+				spec.Paths["/users/{userId}"] = item
+			},
+		},
+		{
+			name:   "OperationRemoved",
+			revise: func(spec *OpenAPI) { spec.Paths["/users/{id}"].Get = nil },
+			want:   []string{"breaking operation-removed GET /users/{id}"},
+		},
+		{
+			name:   "OperationAdded",
+			revise: func(spec *OpenAPI) { spec.Paths["/users/{id}"].Delete = &Operation{} },
+			want:   []string{"non-breaking operation-added DELETE /users/{id}"},
+		},
+		{
+			name:   "OperationDeprecated",
+			revise: func(spec *OpenAPI) { spec.Paths["/users/{id}"].Get.Deprecated = true },
+			want:   []string{"non-breaking operation-deprecated GET /users/{id}"},
+		},
+		{
+			name:   "ParameterRequired",
+			revise: func(spec *OpenAPI) { spec.Paths["/users/{id}"].Get.Parameters[1].Required = true },
+			want:   []string{"breaking parameter-required GET /users/{id}"},
+		},
+		{
+			name: "RequiredParameterAdded",
+			revise: func(spec *OpenAPI) {
+				op := spec.Paths["/users/{id}"].Get
+				op.Parameters = append(op.Parameters, &ParameterObject{Name: "limit", In: "query", Required: true})
+			},
+			want: []string{"breaking parameter-required GET /users/{id}"},
+		},
+		{
+			name: "OptionalParameterAdded",
+			revise: func(spec *OpenAPI) {
+				op := spec.Paths["/users/{id}"].Get
+				op.Parameters = append(op.Parameters, &ParameterObject{Name: "limit", In: "query"})
+			},
+			want: []string{"non-breaking parameter-added GET /users/{id}"},
+		},
+		{
+			name: "ParameterRemoved",
+			revise: func(spec *OpenAPI) {
+				spec.Paths["/users/{id}"].Get.Parameters = spec.Paths["/users/{id}"].Get.Parameters[:1]
+			},
+			want: []string{"non-breaking parameter-removed GET /users/{id}"},
+		},
+		{
+			name:   "RequestBodyRequired",
+			revise: func(spec *OpenAPI) { spec.Paths["/users"].Post.RequestBody.Required = true },
+			want:   []string{"breaking request-body-required POST /users"},
+		},
+		{
+			name:   "RequestBodyRemoved",
+			revise: func(spec *OpenAPI) { spec.Paths["/users"].Post.RequestBody = nil },
+			want:   []string{"non-breaking request-body-removed POST /users"},
+		},
+		{
+			name:   "ResponseRemoved",
+			revise: func(spec *OpenAPI) { delete(spec.Paths["/users/{id}"].Get.Responses, "200") },
+			want:   []string{"breaking response-removed GET /users/{id}"},
+		},
+		{
+			name: "ResponseAdded",
+			revise: func(spec *OpenAPI) {
+				spec.Paths["/users/{id}"].Get.Responses["404"] = &ResponseWrapper{Description: "Not found"}
+			},
+			want: []string{"non-breaking response-added GET /users/{id}"},
+		},
+		{
+			name: "MediaTypeRemoved",
+			revise: func(spec *OpenAPI) {
+				spec.Paths["/users/{id}"].Get.Responses["200"].Content = map[string]MediaType{"application/xml": {}}
+			},
+			want: []string{"breaking media-type-removed GET /users/{id}", "non-breaking media-type-added GET /users/{id}"},
+		},
+		{
+			name:   "ResponsePropertyRemoved",
+			revise: func(spec *OpenAPI) { delete(spec.Components.Schemas["User"].Properties, "status") },
+			want:   []string{"breaking property-removed GET /users/{id}"},
+		},
+		{
+			name:   "ResponsePropertyOptional",
+			revise: func(spec *OpenAPI) { spec.Components.Schemas["User"].Required = nil },
+			want:   []string{"breaking property-optional GET /users/{id}"},
+		},
+		{
+			name:   "RequestPropertyRemoved",
+			revise: func(spec *OpenAPI) { delete(spec.Components.Schemas["NewUser"].Properties, "role") },
+			want:   []string{"non-breaking property-removed POST /users"},
+		},
+		{
+			name:   "RequestPropertyRequired",
+			revise: func(spec *OpenAPI) { spec.Components.Schemas["NewUser"].Required = []string{"name"} },
+			want:   []string{"breaking property-required POST /users"},
+		},
+		{
+			name: "RequiredRequestPropertyAdded",
+			revise: func(spec *OpenAPI) {
+				schema := spec.Components.Schemas["NewUser"]
+				schema.Properties["email"] = &Schema{Type: "string"}
+				schema.Required = []string{"email"}
+			},
+			want: []string{"breaking property-required POST /users"},
+		},
+		{
+			name:   "TypeChanged",
+			revise: func(spec *OpenAPI) { spec.Components.Schemas["User"].Properties["id"].Type = "integer" },
+			want:   []string{"breaking type-changed GET /users/{id}"},
+		},
+		{
+			name: "NullableIsNotATypeChange",
+			revise: func(spec *OpenAPI) {
+				spec.Components.Schemas["User"].Properties["id"] = &Schema{Types: []string{"string", "null"}}
+			},
+		},
+		{
+			name: "ResponseEnumWidened",
+			revise: func(spec *OpenAPI) {
+				status := spec.Components.Schemas["User"].Properties["status"]
+				status.Enum = append(status.Enum, "banned")
+			},
+			want: []string{"breaking enum-widened GET /users/{id}"},
+		},
+		{
+			name:   "ResponseEnumNarrowed",
+			revise: func(spec *OpenAPI) { spec.Components.Schemas["User"].Properties["status"].Enum = []any{"active"} },
+			want:   []string{"non-breaking enum-narrowed GET /users/{id}"},
+		},
+		{
+			name:   "RequestEnumNarrowed",
+			revise: func(spec *OpenAPI) { spec.Components.Schemas["NewUser"].Properties["role"].Enum = []any{"member"} },
+			want:   []string{"breaking enum-narrowed POST /users"},
+		},
+		{
+			name: "RequestEnumWidened",
+			revise: func(spec *OpenAPI) {
+				role := spec.Components.Schemas["NewUser"].Properties["role"]
+				role.Enum = append(role.Enum, "guest")
+			},
+			want: []string{"non-breaking enum-widened POST /users"},
+		},
+		{
+			name:   "WebhookRemoved",
+			revise: func(spec *OpenAPI) { delete(spec.Webhooks, "userCreated") },
+			want:   []string{"breaking operation-removed POST webhook userCreated"},
+		},
+		{
+			name:   "WebhookAdded",
+			revise: func(spec *OpenAPI) { spec.Webhooks["userDeleted"] = &PathItem{Post: &Operation{}} },
+			want:   []string{"non-breaking operation-added POST webhook userDeleted"},
+		},
+		{
+			name:   "WebhookPropertyRemoved",
+			revise: func(spec *OpenAPI) { delete(spec.Components.Schemas["Event"].Properties, "id") },
+			want:   []string{"breaking property-removed POST webhook userCreated"},
+		},
+		{
+			name: "WebhookRequiredPropertyAdded",
+			revise: func(spec *OpenAPI) {
+				schema := spec.Components.Schemas["Event"]
+				schema.Properties["type"] = &Schema{Type: "string"}
+				schema.Required = append(schema.Required, "type")
+			},
+			want: []string{"non-breaking property-added POST webhook userCreated"},
+		},
+		{
+			name:   "WebhookRequestBodyRemoved",
+			revise: func(spec *OpenAPI) { spec.Webhooks["userCreated"].Post.RequestBody = nil },
+			want:   []string{"breaking request-body-removed POST webhook userCreated"},
+		},
+		{
+			name:   "WebhookRequestBodyRequired",
+			revise: func(spec *OpenAPI) { spec.Webhooks["userCreated"].Post.RequestBody.Required = true },
+		},
+		{
+			name: "WebhookRequiredParameterAdded",
+			revise: func(spec *OpenAPI) {
+				spec.Webhooks["userCreated"].Post.Parameters = []*ParameterObject{{Name: "X-Signature", In: "header", Required: true}}
+			},
+			want: []string{"non-breaking parameter-added POST webhook userCreated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision := diffSpec()
+			tt.revise(revision)
+
+			var got []string
+			for _, change := range Diff(diffSpec(), revision) {
+				got = append(got, string(change.Level)+" "+change.Kind+" "+change.Operation)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPointers(t *testing.T) {
+	revision := diffSpec()
+	delete(revision.Components.Schemas["User"].Properties, "status")
+	revision.Webhooks["userCreated"].Post.Responses["202"] = &ResponseWrapper{Description: "Accepted"}
+
+	var got []string
+	for _, change := range Diff(diffSpec(), revision) {
+		got = append(got, change.Pointer)
+	}
+	want := []string{
+		"/components/schemas/User/properties/status",
+		"/webhooks/userCreated/post/responses/202",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Diff() pointers = %q, want %q", got, want)
+	}
+}
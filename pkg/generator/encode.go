@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// FormatFromPath infers the output format from a file extension: yaml for
+// .yaml and .yml files, json otherwise
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// MarshalSpec encodes openapi as indented JSON or YAML. Keys follow the
+// OpenAPI document order (openapi, info, servers, paths, components) and map
+// keys are sorted.
+func MarshalSpec(openapi *OpenAPI, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return json.MarshalIndent(openapi, "", "  ")
+	case FormatYAML, "yml":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		if err := encoder.Encode(openapi); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unsupported format %q (supported: json, yaml)", format)
+}
+
+// UnmarshalSpec decodes a JSON or YAML spec
+func UnmarshalSpec(data []byte, format string) (*OpenAPI, error) {
+	var openapi OpenAPI
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		if err := json.Unmarshal(data, &openapi); err != nil {
+			return nil, err
+		}
+	case FormatYAML, "yml":
+		if err := yaml.Unmarshal(data, &openapi); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q (supported: json, yaml)", format)
+	}
+	return &openapi, nil
+}
+
+// CanonicalSpec re-encodes a JSON or YAML document in format, indented and
+// with every map key sorted. It does not decode the document into an OpenAPI,
+// so fields the generator does not model are kept, which makes it suited for
+// comparing specs regardless of formatting and key order.
+func CanonicalSpec(data []byte, format string) ([]byte, error) {
+	document, err := unmarshalDocument(data, format)
+	if err != nil {
+		return nil, err
+	}
+	document = normalizeDocument(document)
+
+	if strings.ToLower(format) == FormatJSON {
+		return json.MarshalIndent(document, "", "  ")
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalDocument decodes a JSON or YAML document into maps, slices and scalars
+func unmarshalDocument(data []byte, format string) (any, error) {
+	var document any
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		if err := json.Unmarshal(data, &document); err != nil {
+			return nil, err
+		}
+	case FormatYAML, "yml":
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q (supported: json, yaml)", format)
+	}
+	return document, nil
+}
+
+// normalizeDocument turns the maps YAML decodes with non-string keys, such as
+// unquoted response codes, into maps keyed by strings
+func normalizeDocument(node any) any {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, value := range n {
+			n[key] = normalizeDocument(value)
+		}
+	case map[any]any:
+		m := make(map[string]any, len(n))
+		for key, value := range n {
+			m[fmt.Sprint(key)] = normalizeDocument(value)
+		}
+		return m
+	case []any:
+		for i, value := range n {
+			n[i] = normalizeDocument(value)
+		}
+	}
+	return node
+}
+
+// ReadSpec reads a spec file, in the format given by its extension
+func ReadSpec(path string) (*OpenAPI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalSpec(data, FormatFromPath(path))
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	oaparser "github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+	registry := sourceRegistry(t, map[string]string{
+		"example.com/app/api":    "package api\n\ntype Error struct{ Message string `json:\"message\"` }",
+		"example.com/app/dto":    "package dto\n\ntype User struct{ Name string `json:\"name\"`; Nickname *string `json:\"nickname\"` }",
+		"example.com/app/models": "package models\n\ntype User struct{ ID string `json:\"id\"` }",
+	})
+	metadata := oaparser.GlobalMetadata{GlobalTitle: "Users", GlobalVersion: "1.0.0"}
+
+	for _, version := range []string{OpenAPIVersion30, OpenAPIVersion31} {
+		t.Run(version, func(t *testing.T) {
+			spec, _, err := GenerateSpecWithOptions(orderRoutes(), registry, metadata, Options{OpenAPIVersion: version, NullablePointers: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := MarshalSpec(spec, FormatJSON)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// JSON to OpenAPI to YAML to OpenAPI to JSON
+			decoded, err := UnmarshalSpec(want, FormatJSON)
+			if err != nil {
+				t.Fatal(err)
+			}
+			yamlData, err := MarshalSpec(decoded, FormatYAML)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fromYAML, err := UnmarshalSpec(yamlData, FormatYAML)
+			if err != nil {
+				t.Fatalf("UnmarshalSpec(yaml) = %v for:\n%s", err, yamlData)
+			}
+			got, err := MarshalSpec(fromYAML, FormatJSON)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("spec after a YAML round trip:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestYAMLResponseCodes(t *testing.T) {
+	const unquoted = `openapi: 3.0.3
+info: {title: Users, version: "1"}
+paths:
+  /users:
+    get:
+      responses:
+        200:
+          description: OK
+        default:
+          description: Error
+`
+	const quoted = `openapi: 3.0.3
+info: {title: Users, version: "1"}
+paths:
+  /users:
+    get:
+      responses:
+        "default":
+          description: Error
+        "200":
+          description: OK
+`
+
+	spec, err := UnmarshalSpec([]byte(unquoted), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp := spec.Paths["/users"].Get.Responses["200"]; resp == nil || resp.Description != "OK" {
+		t.Errorf("response 200 = %+v, want OK", resp)
+	}
+
+	document, err := unmarshalDocument([]byte(unquoted), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// encoding/json cannot encode the map[any]any YAML decodes 200: into
+	if _, err := json.Marshal(normalizeDocument(document)); err != nil {
+		t.Errorf("normalized document cannot be encoded as JSON: %v", err)
+	}
+
+	got, err := CanonicalSpec([]byte(unquoted), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := CanonicalSpec([]byte(quoted), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("CanonicalSpec() of unquoted codes =\n%s\nwant, as for quoted ones:\n%s", got, want)
+	}
+}
+
+func TestYAMLSchemaTypes(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"nickname": {Types: []string{"string", "null"}},
+			"age":      {Type: "integer"},
+		},
+	}
+	spec := &OpenAPI{
+		OpenAPI:    OpenAPIVersion31,
+		Info:       Info{Title: "Users", Version: "1"},
+		Components: &Components{Schemas: map[string]*Schema{"User": schema}},
+	}
+
+	data, err := MarshalSpec(spec, FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "- string\n") || !strings.Contains(string(data), "- \"null\"\n") {
+		t.Errorf("type list is not written as a block sequence:\n%s", data)
+	}
+
+	const flow = `openapi: 3.1.0
+info: {title: Users, version: "1"}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        nickname: {type: [string, "null"], description: Nickname}
+        age: {type: integer}
+`
+	for name, data := range map[string][]byte{"Encoded": data, "Flow": []byte(flow)} {
+		decoded, err := UnmarshalSpec(data, FormatYAML)
+		if err != nil {
+			t.Fatal(err)
+		}
+		props := decoded.Components.Schemas["User"].Properties
+		if nickname := props["nickname"]; nickname.Type != "" || !slices.Equal(nickname.Types, []string{"string", "null"}) {
+			t.Errorf("%s: nickname = %+v, want the type list", name, nickname)
+		}
+		if age := props["age"]; age.Type != "integer" || age.Types != nil {
+			t.Errorf("%s: age = %+v, want type integer", name, age)
+		}
+	}
+	if desc := mustUnmarshalYAML(t, flow).Components.Schemas["User"].Properties["nickname"].Description; desc != "Nickname" {
+		t.Errorf("nickname description = %q, want the keys next to the type list", desc)
+	}
+}
+
+func mustUnmarshalYAML(t *testing.T, data string) *OpenAPI {
+	t.Helper()
+	spec, err := UnmarshalSpec([]byte(data), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
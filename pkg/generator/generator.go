@@ -1,24 +1,72 @@
 package generator
 
 import (
-	"fmt"
+	"go/types"
 	"log"
+	"maps"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/georgetjose/openapi3gen/pkg/parser"
 )
 
-// GenerateSpec builds an OpenAPI struct from parsed RouteDoc list
+// GenerateSpec builds an OpenAPI 3.0 struct from parsed RouteDoc list.
+// Diagnostics are logged; use GenerateSpecWithOptions to receive them.
 func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaData parser.GlobalMetadata) *OpenAPI {
+	openapi, diags, _ := GenerateSpecWithOptions(routes, registry, globalMetaData, Options{})
+	for _, d := range diags {
+		log.Println(d)
+	}
+	return openapi
+}
+
+// GenerateSpecWithOptions builds an OpenAPI struct of the version selected by
+// opts, returning the problems found as diagnostics. In strict mode every
+// diagnostic is an error and the error summarises them.
+func GenerateSpecWithOptions(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaData parser.GlobalMetadata, opts Options) (*OpenAPI, parser.Diagnostics, error) {
+	version, err := resolveOpenAPIVersion(opts.OpenAPIVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var diags parser.Diagnostics
+	openapi := generateSpec(routes, registry, globalMetaData, opts, &diags)
+	openapi.OpenAPI = version
+	if version == OpenAPIVersion31 {
+		convertTo31(openapi)
+	} else {
+		convertTo30(openapi, &diags)
+	}
+
+	if opts.Strict {
+		diags.Strict()
+	}
+	return openapi, diags, diags.Err()
+}
+
+func generateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaData parser.GlobalMetadata, opts Options, diags *parser.Diagnostics) *OpenAPI {
 	openapi := &OpenAPI{
-		OpenAPI: "3.0.0",
+		OpenAPI: OpenAPIVersion30,
 		Info: Info{
 			Title:       globalMetaData.GlobalTitle,
 			Version:     globalMetaData.GlobalVersion,
 			Description: globalMetaData.GlobalDescription,
 		},
-		Paths: make(map[string]*PathItem),
+		Servers: opts.Servers,
+		Paths:   make(map[string]*PathItem),
+	}
+	if globalMetaData.GlobalLicense != "" || globalMetaData.GlobalLicenseIdentifier != "" {
+		openapi.Info.License = &License{
+			Name:       globalMetaData.GlobalLicense,
+			Identifier: globalMetaData.GlobalLicenseIdentifier,
+			URL:        globalMetaData.GlobalLicenseURL,
+		}
+		if openapi.Info.License.Name == "" {
+			openapi.Info.License.Name = globalMetaData.GlobalLicenseIdentifier
+		}
 	}
 	openapi.Components = &Components{
 		Schemas:         make(map[string]*Schema),
@@ -69,11 +117,38 @@ func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaD
 		}
 	}
 
-	for _, route := range routes {
-		pathItem, exists := openapi.Paths[route.Path]
+	schemaOpts := &schemaOptions{
+		names:            newSchemaNames(routeModelTypes(routes, registry, opts), diags),
+		nullablePointers: opts.NullablePointers,
+	}
+	ambiguous := registry.ambiguousNames()
+
+	operationIDs := make(map[string]bool)
+	for _, route := range sortedRoutes(routes) {
+		methods := []string{strings.ToLower(route.Method)}
+		if methods[0] == "any" {
+			// gin's Any registers the route for every method
+			methods = HTTPMethods
+		} else if (&PathItem{}).operation(methods[0]) == nil {
+			diags.Warnf(parser.CodeUnknownMethod, route.Position, "", "unknown HTTP method %q for route %s%s, skipping it", route.Method, route.Path, route.Webhook)
+			continue
+		}
+
+		paths, key, ptr := openapi.Paths, route.Path, pointer("/paths", route.Path)
+		if route.Webhook != "" {
+			if openapi.Webhooks == nil {
+				openapi.Webhooks = make(map[string]*PathItem)
+			}
+			paths, key, ptr = openapi.Webhooks, route.Webhook, pointer("/webhooks", route.Webhook)
+		}
+		if len(methods) == 1 {
+			ptr = pointer(ptr, methods[0])
+		}
+
+		pathItem, exists := paths[key]
 		if !exists {
 			pathItem = &PathItem{}
-			openapi.Paths[route.Path] = pathItem
+			paths[key] = pathItem
 		}
 
 		// 🔹 Reset parameters and requestBody per route
@@ -82,6 +157,7 @@ func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaD
 
 		// 🔹 Deduplication map
 		seenParams := make(map[string]bool)
+		var formFields []string
 
 		for _, p := range route.Params {
 			paramKey := p.In + ":" + p.Name
@@ -91,24 +167,39 @@ func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaD
 			seenParams[paramKey] = true
 
 			if p.In == "path" && !strings.Contains(route.Path, "{"+p.Name+"}") {
-				fmt.Printf("Warning: Path param '%s' not found in route path '%s'. Skipping.\n", p.Name, route.Path)
+				diags.Warnf(parser.CodePathParamMismatch, route.Position, pointer(ptr, "parameters"), "path parameter %q not found in route path %s, skipping it", p.Name, route.Path)
 				continue
 			}
 
+			if p.Form && p.In == "query" {
+				formFields = append(formFields, p.Name)
+			}
 			parameters = append(parameters, &ParameterObject{
 				Name:        p.Name,
 				In:          p.In,
 				Required:    p.Required,
 				Description: p.Description,
 				Schema: &Schema{
-					Type: p.Schema,
+					Type:    p.Schema,
+					Pattern: p.Pattern,
 				},
 			})
 		}
 
+		// getModel looks up a model and the name it is registered under,
+		// reporting the names matching types of several packages
+		getModel := func(name, ptr string) (string, any, bool) {
+			registered, m, ok := registry.lookup(name)
+			if t, isType := m.(types.Type); isType && len(ambiguous[name]) > 0 {
+				diags.Warnf(parser.CodeAmbiguousModel, route.Position, ptr, "model %s matches %s, using %s; qualify it with the import path to use another",
+					name, strings.Join(ambiguous[name], ", "), typeKey(t))
+			}
+			return registered, m, ok
+		}
+
 		if route.RequestBody != nil {
-			if m, ok := registry.Get(route.RequestBody.Model); ok {
-				refSchema := addComponentSchema(route.RequestBody.Model, m, openapi.Components)
+			if name, m, ok := getModel(route.RequestBody.Model, pointer(ptr, "requestBody")); ok {
+				refSchema := addComponentSchema(name, m, openapi.Components, schemaOpts)
 
 				requestBody = &RequestBodyObject{
 					Description: route.RequestBody.Description,
@@ -120,12 +211,12 @@ func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaD
 					},
 				}
 			} else {
-				log.Printf("Model not found in registry: %s\n", route.RequestBody.Model)
+				diags.Warnf(parser.CodeMissingModel, route.Position, pointer(ptr, "requestBody"), "request body model %s not found in registry", route.RequestBody.Model)
 			}
 		}
 
 		responses := make(map[string]*ResponseWrapper)
-		for statusCode, r := range route.Responses {
+		addResponse := func(statusCode string, r parser.Response) {
 			// Collect response headers
 			headers := make(map[string]*HeaderObject)
 			for _, h := range route.Headers {
@@ -151,22 +242,26 @@ func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaD
 
 			// Only add content if there's a model
 			if r.Model != "" {
-				if m, ok := registry.Get(r.Model); ok {
-					refSchema := addComponentSchema(r.Model, m, openapi.Components)
+				if name, m, ok := getModel(r.Model, pointer(ptr, "responses", statusCode)); ok {
+					refSchema := addComponentSchema(name, m, openapi.Components, schemaOpts)
 					response.Content = map[string]MediaType{
 						r.MediaType: {
 							Schema: refSchema,
 						},
 					}
 				} else {
-					log.Printf("Model not found in registry: %s\n", r.Model)
-					continue // Skip this response if model not found
+					diags.Warnf(parser.CodeMissingModel, route.Position, pointer(ptr, "responses", statusCode), "response model %s not found in registry", r.Model)
+					return // Skip this response if model not found
 				}
 			}
 
 			responses[statusCode] = response
 		}
 
+		for _, statusCode := range slices.Sorted(maps.Keys(route.Responses)) {
+			addResponse(statusCode, route.Responses[statusCode])
+		}
+
 		// Ensure at least 1 response
 		if len(responses) == 0 {
 			responses["200"] = &ResponseWrapper{
@@ -174,10 +269,25 @@ func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaD
 			}
 		}
 
+		// Default responses apply to the routes that do not document the status code themselves
+		if route.Webhook == "" {
+			for _, statusCode := range slices.Sorted(maps.Keys(opts.DefaultResponses)) {
+				if _, ok := route.Responses[statusCode]; !ok {
+					r := opts.DefaultResponses[statusCode]
+					if r.MediaType == "" {
+						r.MediaType = "application/json"
+					}
+					addResponse(statusCode, r)
+				}
+			}
+		}
+
+		sortParameters(parameters)
+
 		op := &Operation{
 			Summary:     route.Summary,
 			Description: route.Description,
-			Tags:        route.Tags,
+			Tags:        uniqueTags(route.Tags),
 			Parameters:  parameters,
 			RequestBody: requestBody,
 			Responses:   responses,
@@ -199,22 +309,127 @@ func GenerateSpec(routes []parser.RouteDoc, registry *ModelRegistry, globalMetaD
 			op.Deprecated = true
 		}
 
-		switch strings.ToLower(route.Method) {
-		case "get":
-			pathItem.Get = op
-		case "post":
-			pathItem.Post = op
-		case "put":
-			pathItem.Put = op
-		case "delete":
-			pathItem.Delete = op
+		for _, method := range methods {
+			// Every method gets its own copy, so that editing one operation of
+			// the spec does not change the others
+			methodOp := op.clone()
+			if len(formFields) > 0 && slices.Contains(formBodyMethods, method) {
+				moveToFormBody(methodOp, formFields)
+			}
+			id := operationIDBase(route)
+			if len(methods) > 1 {
+				id += strings.ToUpper(method[:1]) + method[1:]
+			}
+			methodOp.OperationID = uniqueOperationID(id, operationIDs)
+			pathItem.SetOperation(method, methodOp)
 		}
 	}
 
+	// Configured security schemes replace the ones derived from @Security names
+	maps.Copy(openapi.Components.SecuritySchemes, opts.SecuritySchemes)
+
+	openapi.Tags = specTags(openapi)
+
 	return openapi
 }
 
-func addComponentSchema(modelName string, model any, components *Components) *Schema {
+// routeModelTypes returns the types of the source models used by routes and
+// the default responses, whose component names are chosen together
+func routeModelTypes(routes []parser.RouteDoc, registry *ModelRegistry, opts Options) []types.Type {
+	var models []string
+	for _, route := range routes {
+		if route.RequestBody != nil {
+			models = append(models, route.RequestBody.Model)
+		}
+		for _, r := range route.Responses {
+			models = append(models, r.Model)
+		}
+	}
+	for _, r := range opts.DefaultResponses {
+		models = append(models, r.Model)
+	}
+
+	var modelTypes []types.Type
+	for _, name := range models {
+		if _, m, ok := registry.lookup(name); ok {
+			if t, ok := m.(types.Type); ok {
+				modelTypes = append(modelTypes, t)
+			}
+		}
+	}
+	return modelTypes
+}
+
+// formBodyMethods are the methods whose form body net/http's r.FormValue reads
+var formBodyMethods = []string{"post", "put", "patch"}
+
+// moveToFormBody documents the query parameters of op named by fields as the
+// fields of an application/x-www-form-urlencoded request body instead
+func moveToFormBody(op *Operation, fields []string) {
+	form := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	op.Parameters = slices.DeleteFunc(op.Parameters, func(p *ParameterObject) bool {
+		if p.In != "query" || !slices.Contains(fields, p.Name) {
+			return false
+		}
+		field := p.Schema
+		if field == nil {
+			field = &Schema{Type: "string"}
+		}
+		field.Description = p.Description
+		form.Properties[p.Name] = field
+		return true
+	})
+	if len(form.Properties) == 0 {
+		return
+	}
+
+	if op.RequestBody == nil {
+		op.RequestBody = &RequestBodyObject{}
+	}
+	if op.RequestBody.Content == nil {
+		op.RequestBody.Content = make(map[string]MediaType)
+	}
+	if _, ok := op.RequestBody.Content["application/x-www-form-urlencoded"]; !ok {
+		op.RequestBody.Content["application/x-www-form-urlencoded"] = MediaType{Schema: form}
+	}
+}
+
+// operationIDBase returns the preferred operationId of a route: its explicit
+// operationId, else the handler name, else one derived from method and path
+func operationIDBase(route parser.RouteDoc) string {
+	if route.OperationID != "" {
+		return route.OperationID
+	}
+	if route.Handler != "" {
+		return route.Handler
+	}
+
+	id := strings.ToLower(route.Method)
+	for _, segment := range strings.FieldsFunc(route.Path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		id += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return id
+}
+
+// uniqueOperationID returns id, numbered if it is already used, and marks it used.
+// A handler documented under several routes gets GetUser, GetUser2 and so on.
+func uniqueOperationID(id string, used map[string]bool) string {
+	unique := id
+	for n := 2; used[unique]; n++ {
+		unique = id + strconv.Itoa(n)
+	}
+	used[unique] = true
+	return unique
+}
+
+func addComponentSchema(modelName string, model any, components *Components, opts *schemaOptions) *Schema {
+	// Models discovered from source are keyed by their declared type name
+	if t, ok := model.(types.Type); ok {
+		return addComponentTypeSchema(t, components, opts)
+	}
+
 	// If already registered, return $ref
 	if _, exists := components.Schemas[modelName]; exists {
 		return &Schema{
@@ -222,19 +437,34 @@ func addComponentSchema(modelName string, model any, components *Components) *Sc
 		}
 	}
 
-	schema := GenerateSchemaFromStruct(model)
+	schema := structSchema(model, opts)
 	components.Schemas[modelName] = schema
 
 	// Recursively register nested struct schemas
-	registerNestedSchemas(model, components)
+	registerNestedSchemas(model, components, opts)
 
 	return &Schema{
 		Ref: "#/components/schemas/" + modelName,
 	}
 }
 
+func addComponentTypeSchema(t types.Type, components *Components, opts *schemaOptions) *Schema {
+	schemaName := opts.name(t)
+
+	if _, exists := components.Schemas[schemaName]; !exists {
+		components.Schemas[schemaName] = typeSchema(t, opts)
+
+		// Recursively register nested struct schemas
+		registerNestedTypeSchemas(t, components, opts)
+	}
+
+	return &Schema{
+		Ref: "#/components/schemas/" + schemaName,
+	}
+}
+
 // registerNestedSchemas recursively registers schemas for nested structs
-func registerNestedSchemas(model any, components *Components) {
+func registerNestedSchemas(model any, components *Components, opts *schemaOptions) {
 	t := reflect.TypeOf(model)
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -248,7 +478,8 @@ func registerNestedSchemas(model any, components *Components) {
 		field := t.Field(i)
 		fieldType := field.Type
 
-		if fieldType.Kind() == reflect.Ptr {
+		// Look through pointers and the elements of slices and arrays
+		for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
 			fieldType = fieldType.Elem()
 		}
 
@@ -260,11 +491,11 @@ func registerNestedSchemas(model any, components *Components) {
 			if _, exists := components.Schemas[schemaName]; !exists {
 				// Create an instance of the struct to generate schema
 				structValue := reflect.New(fieldType).Interface()
-				schema := GenerateSchemaFromStruct(structValue)
+				schema := structSchema(structValue, opts)
 				components.Schemas[schemaName] = schema
 
 				// Recursively register nested structs
-				registerNestedSchemas(structValue, components)
+				registerNestedSchemas(structValue, components, opts)
 			}
 		}
 	}
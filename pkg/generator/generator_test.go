@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	oaparser "github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+func TestAnyRoute(t *testing.T) {
+	routes := []oaparser.RouteDoc{{
+		Method:  "any",
+		Path:    "/users/{id}",
+		Handler: "UserHandler",
+		Params:  []oaparser.Parameter{{Name: "id", In: "path", Schema: "string", Required: true}},
+		Headers: []oaparser.Header{{StatusCode: "200", Name: "X-Request-ID", Type: "string"}},
+		Responses: map[string]oaparser.Response{
+			"200": {StatusCode: "200", Description: "OK"},
+		},
+		SecuritySchemes: []oaparser.SecurityScheme{{Name: "BearerAuth"}},
+	}}
+	spec, _, err := GenerateSpecWithOptions(routes, NewModelRegistry(), oaparser.GlobalMetadata{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := spec.Paths["/users/{id}"]
+	ops := item.Operations()
+	if len(ops) != len(HTTPMethods) {
+		t.Fatalf("operations = %d, want one per method (%d)", len(ops), len(HTTPMethods))
+	}
+	ids := make(map[string]string)
+	for method, op := range ops {
+		if other, ok := ids[op.OperationID]; ok {
+			t.Errorf("%s and %s share the operationId %s", method, other, op.OperationID)
+		}
+		ids[op.OperationID] = method
+	}
+	if item.Get.OperationID != "UserHandlerGet" || item.Post.OperationID != "UserHandlerPost" {
+		t.Errorf("operationIds = %s, %s, want UserHandlerGet, UserHandlerPost", item.Get.OperationID, item.Post.OperationID)
+	}
+
+	// Editing one operation leaves the others as they were
+	item.Get.Parameters[0].Description = "edited"
+	item.Get.Parameters[0].Schema.Type = "integer"
+	item.Get.Responses["200"].Description = "edited"
+	item.Get.Responses["200"].Headers["X-Request-ID"].Schema.Type = "integer"
+	item.Get.Responses["404"] = &ResponseWrapper{Description: "Not found"}
+	item.Get.Security[0]["BearerAuth"] = append(item.Get.Security[0]["BearerAuth"], "admin")
+	post := item.Post
+	if post.Parameters[0].Description == "edited" || post.Parameters[0].Schema.Type != "string" {
+		t.Errorf("editing the GET parameter changed the POST one: %+v", post.Parameters[0])
+	}
+	if resp := post.Responses["200"]; resp.Description == "edited" || resp.Headers["X-Request-ID"].Schema.Type != "string" {
+		t.Errorf("editing the GET response changed the POST one: %+v", resp)
+	}
+	if _, ok := post.Responses["404"]; ok {
+		t.Error("adding a GET response added it to POST")
+	}
+	if len(post.Security[0]["BearerAuth"]) != 0 {
+		t.Errorf("editing the GET security changed the POST one: %v", post.Security)
+	}
+}
+
+func TestFormParameters(t *testing.T) {
+	routes := []oaparser.RouteDoc{{
+		Method:  "any",
+		Path:    "/users",
+		Handler: "UserHandler",
+		Params: []oaparser.Parameter{
+			{Name: "name", In: "query", Schema: "string", Description: "Form value 'name'", Form: true},
+			{Name: "page", In: "query", Schema: "string"},
+		},
+		Responses: map[string]oaparser.Response{
+			"200": {StatusCode: "200", Description: "OK"},
+		},
+	}}
+	spec, _, err := GenerateSpecWithOptions(routes, NewModelRegistry(), oaparser.GlobalMetadata{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := spec.Paths["/users"]
+	if get := item.Get; len(get.Parameters) != 2 || get.RequestBody != nil {
+		t.Errorf("GET parameters = %d, request body = %+v, want both query parameters and no body", len(get.Parameters), get.RequestBody)
+	}
+	for method, op := range map[string]*Operation{"post": item.Post, "put": item.Put, "patch": item.Patch} {
+		if len(op.Parameters) != 1 || op.Parameters[0].Name != "page" {
+			t.Errorf("%s parameters = %+v, want only page", method, op.Parameters)
+		}
+		if op.RequestBody == nil {
+			t.Errorf("%s has no request body", method)
+			continue
+		}
+		form := op.RequestBody.Content["application/x-www-form-urlencoded"].Schema
+		if form == nil || form.Properties["name"] == nil || form.Properties["name"].Description != "Form value 'name'" {
+			t.Errorf("%s form body = %+v, want the name field", method, form)
+		}
+	}
+}
+
+func TestOperationIDs(t *testing.T) {
+	ok := map[string]oaparser.Response{"200": {StatusCode: "200", Description: "OK"}}
+	routes := []oaparser.RouteDoc{
+		{Method: "get", Path: "/users/{id}", Handler: "GetUser", Responses: ok},
+		{Method: "head", Path: "/users/{id}", Handler: "GetUser", OperationID: "headUser", Responses: ok},
+		{Method: "get", Path: "/v2/users/{id}", Handler: "GetUser", Responses: ok},
+		{Method: "get", Path: "/people/{id}", Handler: "GetPerson", OperationID: "GetUser", Responses: ok},
+		{Method: "get", Path: "/health", Responses: ok},
+	}
+	spec, _, err := GenerateSpecWithOptions(routes, NewModelRegistry(), oaparser.GlobalMetadata{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]string{
+		"GET /users/{id}":    spec.Paths["/users/{id}"].Get.OperationID,
+		"HEAD /users/{id}":   spec.Paths["/users/{id}"].Head.OperationID,
+		"GET /v2/users/{id}": spec.Paths["/v2/users/{id}"].Get.OperationID,
+		"GET /people/{id}":   spec.Paths["/people/{id}"].Get.OperationID,
+		"GET /health":        spec.Paths["/health"].Get.OperationID,
+	}
+	// Routes are numbered in path order
+	want := map[string]string{
+		"GET /users/{id}":    "GetUser2",
+		"HEAD /users/{id}":   "headUser",
+		"GET /v2/users/{id}": "GetUser3",
+		"GET /people/{id}":   "GetUser",
+		"GET /health":        "getHealth",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("operationIds = %v, want %v", got, want)
+	}
+}
+
+func TestUniqueOperationID(t *testing.T) {
+	used := make(map[string]bool)
+	var got []string
+	for _, id := range []string{"GetUser", "GetUser", "GetUser2", "GetUser", "headUser"} {
+		got = append(got, uniqueOperationID(id, used))
+	}
+	want := []string{"GetUser", "GetUser2", "GetUser22", "GetUser3", "headUser"}
+	if !slices.Equal(got, want) {
+		t.Errorf("uniqueOperationID() = %q, want %q", got, want)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		spec, diags, err := GenerateSpecWithOptions(responseRoutes("Missing"), NewModelRegistry(), oaparser.GlobalMetadata{}, Options{Strict: strict})
+		if spec == nil || spec.Paths["/a"] == nil {
+			t.Errorf("strict %v: spec lacks /a", strict)
+		}
+		if len(diags) != 1 || diags[0].Code != oaparser.CodeMissingModel {
+			t.Fatalf("strict %v: diagnostics = %v, want a %s", strict, diags, oaparser.CodeMissingModel)
+		}
+
+		want := oaparser.SeverityWarning
+		if strict {
+			want = oaparser.SeverityError
+		}
+		if diags[0].Severity != want {
+			t.Errorf("strict %v: severity = %s, want %s", strict, diags[0].Severity, want)
+		}
+		if (err != nil) != strict {
+			t.Errorf("strict %v: err = %v", strict, err)
+		}
+	}
+}
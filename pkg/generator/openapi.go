@@ -1,59 +1,283 @@
 package generator
 
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 type Schema struct {
 	Type        string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Types       []string           `json:"-" yaml:"-"` // OpenAPI 3.1 type list such as ["string", "null"], written instead of Type
 	Properties  map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
 	Items       *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
 	Ref         string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Pattern     string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Enum        []any              `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Required    []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"` // OpenAPI 3.0 only
+	Example     any                `json:"example,omitempty" yaml:"example,omitempty"`   // OpenAPI 3.0 only
+	Examples    []any              `json:"examples,omitempty" yaml:"examples,omitempty"` // OpenAPI 3.1 only
+}
+
+// clone returns a deep copy of s
+func (s *Schema) clone() *Schema {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Types = slices.Clone(s.Types)
+	if s.Properties != nil {
+		c.Properties = make(map[string]*Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			c.Properties[name] = prop.clone()
+		}
+	}
+	c.Items = s.Items.clone()
+	c.Enum = slices.Clone(s.Enum)
+	c.Required = slices.Clone(s.Required)
+	c.Examples = slices.Clone(s.Examples)
+	return &c
+}
+
+// MarshalJSON writes Types, when set, as the type keyword
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	if len(s.Types) == 0 {
+		return json.Marshal(schema(s))
+	}
+	return json.Marshal(struct {
+		Type []string `json:"type"`
+		schema
+	}{s.Types, schema(s)})
+}
+
+// MarshalYAML writes Types, when set, as the type keyword
+func (s Schema) MarshalYAML() (any, error) {
+	type schema Schema
+	if len(s.Types) == 0 {
+		return schema(s), nil
+	}
+
+	var node, types yaml.Node
+	if err := node.Encode(schema(s)); err != nil {
+		return nil, err
+	}
+	if err := types.Encode(s.Types); err != nil {
+		return nil, err
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "type"}
+	node.Content = append([]*yaml.Node{key, &types}, node.Content...)
+	// A schema with nothing but the type list is encoded as an empty {} mapping
+	node.Style &^= yaml.FlowStyle
+	return &node, nil
+}
+
+// UnmarshalYAML reads the type keyword into Type, or into Types when it is a list
+func (s *Schema) UnmarshalYAML(value *yaml.Node) error {
+	type schema Schema
+
+	// Decode everything but a type list, which does not fit Type
+	mapping := *value
+	mapping.Content = nil
+	var types *yaml.Node
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if value.Content[i].Value == "type" && value.Content[i+1].Kind == yaml.SequenceNode {
+			types = value.Content[i+1]
+			continue
+		}
+		mapping.Content = append(mapping.Content, value.Content[i], value.Content[i+1])
+	}
+
+	if err := mapping.Decode((*schema)(s)); err != nil {
+		return err
+	}
+	if types != nil {
+		return types.Decode(&s.Types)
+	}
+	return nil
+}
+
+// UnmarshalJSON reads the type keyword into Type, or into Types when it is a list
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type schema Schema
+	var raw struct {
+		schema
+		Type json.RawMessage `json:"type"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = Schema(raw.schema)
+	if len(raw.Type) == 0 {
+		return nil
+	}
+	if raw.Type[0] == '[' {
+		return json.Unmarshal(raw.Type, &s.Types)
+	}
+	return json.Unmarshal(raw.Type, &s.Type)
 }
 
 type Components struct {
 	Schemas         map[string]*Schema               `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Responses       map[string]*ResponseWrapper      `json:"responses,omitempty" yaml:"responses,omitempty"`
+	Parameters      map[string]*ParameterObject      `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBodies   map[string]*RequestBodyObject    `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
 	Ref             string                           `json:"$ref,omitempty" yaml:"$ref,omitempty"`
-	SecuritySchemes map[string]*SecuritySchemeObject `json:"securitySchemes,omitempty"`
+	SecuritySchemes map[string]*SecuritySchemeObject `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
 }
 
 type OpenAPI struct {
 	OpenAPI    string               `json:"openapi" yaml:"openapi"`
 	Info       Info                 `json:"info" yaml:"info"`
+	Servers    []Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
 	Paths      map[string]*PathItem `json:"paths" yaml:"paths"`
+	Webhooks   map[string]*PathItem `json:"webhooks,omitempty" yaml:"webhooks,omitempty"` // OpenAPI 3.1 only
 	Components *Components          `json:"components,omitempty" yaml:"components,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 type Info struct {
-	Title       string `json:"title" yaml:"title"`
-	Version     string `json:"version" yaml:"version"`
+	Title       string   `json:"title" yaml:"title"`
+	Version     string   `json:"version" yaml:"version"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	License     *License `json:"license,omitempty" yaml:"license,omitempty"`
+}
+
+type Server struct {
+	URL         string `json:"url" yaml:"url"`
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
+type License struct {
+	Name       string `json:"name" yaml:"name"`
+	Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"` // SPDX expression, OpenAPI 3.1 only
+	URL        string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
 type PathItem struct {
-	Get    *Operation `json:"get,omitempty" yaml:"get,omitempty"`
-	Post   *Operation `json:"post,omitempty" yaml:"post,omitempty"`
-	Put    *Operation `json:"put,omitempty" yaml:"put,omitempty"`
-	Delete *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Parameters []*ParameterObject `json:"parameters,omitempty" yaml:"parameters,omitempty"` // Shared by every operation
+	Get        *Operation         `json:"get,omitempty" yaml:"get,omitempty"`
+	Put        *Operation         `json:"put,omitempty" yaml:"put,omitempty"`
+	Post       *Operation         `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete     *Operation         `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options    *Operation         `json:"options,omitempty" yaml:"options,omitempty"`
+	Head       *Operation         `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch      *Operation         `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Trace      *Operation         `json:"trace,omitempty" yaml:"trace,omitempty"`
+}
+
+// HTTPMethods are the lower case HTTP methods a PathItem can hold an operation for
+var HTTPMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// operation returns the field holding the operation for method, or nil for unknown methods
+func (p *PathItem) operation(method string) **Operation {
+	switch strings.ToLower(method) {
+	case "get":
+		return &p.Get
+	case "put":
+		return &p.Put
+	case "post":
+		return &p.Post
+	case "delete":
+		return &p.Delete
+	case "options":
+		return &p.Options
+	case "head":
+		return &p.Head
+	case "patch":
+		return &p.Patch
+	case "trace":
+		return &p.Trace
+	}
+	return nil
+}
+
+// Operations returns the operations of the path item keyed by lower case HTTP method
+func (p *PathItem) Operations() map[string]*Operation {
+	operations := make(map[string]*Operation)
+	for _, method := range HTTPMethods {
+		if op := *p.operation(method); op != nil {
+			operations[method] = op
+		}
+	}
+	return operations
+}
+
+// SetOperation sets the operation for method, reporting false if method is not an HTTP method
+func (p *PathItem) SetOperation(method string, op *Operation) bool {
+	field := p.operation(method)
+	if field == nil {
+		return false
+	}
+	*field = op
+	return true
 }
 
 type SecuritySchemeObject struct {
-	Type         string `json:"type"`
-	Scheme       string `json:"scheme,omitempty"`
-	BearerFormat string `json:"bearerFormat,omitempty"`
-	Name         string `json:"name,omitempty"` // For API Key: header/query/cookie name
-	In           string `json:"in,omitempty"`   // For API Key: "header", "query", or "cookie"
+	Type         string `json:"type" yaml:"type"`
+	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"` // For API Key: header/query/cookie name
+	In           string `json:"in,omitempty" yaml:"in,omitempty"`     // For API Key: "header", "query", or "cookie"
 }
 
 type Operation struct {
+	OperationID string                      `json:"operationId,omitempty" yaml:"operationId,omitempty"`
 	Summary     string                      `json:"summary,omitempty" yaml:"summary,omitempty"`
 	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
 	Tags        []string                    `json:"tags,omitempty" yaml:"tags,omitempty"`
 	Responses   map[string]*ResponseWrapper `json:"responses,omitempty" yaml:"responses,omitempty"`
 	Parameters  []*ParameterObject          `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 	RequestBody *RequestBodyObject          `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
-	Security    []map[string][]string       `json:"security,omitempty"`
+	Security    []map[string][]string       `json:"security,omitempty" yaml:"security,omitempty"`
 	Deprecated  bool                        `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 }
 
+// clone returns a deep copy of op, sharing nothing with it
+func (op *Operation) clone() *Operation {
+	if op == nil {
+		return nil
+	}
+	c := *op
+	c.Tags = slices.Clone(op.Tags)
+	if op.Responses != nil {
+		c.Responses = make(map[string]*ResponseWrapper, len(op.Responses))
+		for code, resp := range op.Responses {
+			c.Responses[code] = resp.clone()
+		}
+	}
+	if op.Parameters != nil {
+		c.Parameters = make([]*ParameterObject, len(op.Parameters))
+		for i, param := range op.Parameters {
+			c.Parameters[i] = param.clone()
+		}
+	}
+	c.RequestBody = op.RequestBody.clone()
+	if op.Security != nil {
+		c.Security = make([]map[string][]string, len(op.Security))
+		for i, requirement := range op.Security {
+			c.Security[i] = make(map[string][]string, len(requirement))
+			for name, scopes := range requirement {
+				c.Security[i][name] = slices.Clone(scopes)
+			}
+		}
+	}
+	return &c
+}
+
 type ResponseWrapper struct {
+	Ref         string                   `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Description string                   `json:"description" yaml:"description"`
 	Content     map[string]MediaType     `json:"content,omitempty" yaml:"content,omitempty"`
 	Headers     map[string]*HeaderObject `json:"headers,omitempty" yaml:"headers,omitempty"`
@@ -63,7 +287,35 @@ type MediaType struct {
 	Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
 }
 
+func (r *ResponseWrapper) clone() *ResponseWrapper {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	c.Content = cloneContent(r.Content)
+	if r.Headers != nil {
+		c.Headers = make(map[string]*HeaderObject, len(r.Headers))
+		for name, header := range r.Headers {
+			c.Headers[name] = header.clone()
+		}
+	}
+	return &c
+}
+
+// cloneContent returns a deep copy of content
+func cloneContent(content map[string]MediaType) map[string]MediaType {
+	if content == nil {
+		return nil
+	}
+	c := make(map[string]MediaType, len(content))
+	for mediaType, media := range content {
+		c[mediaType] = MediaType{Schema: media.Schema.clone()}
+	}
+	return c
+}
+
 type ParameterObject struct {
+	Ref         string  `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Name        string  `json:"name" yaml:"name"`
 	In          string  `json:"in" yaml:"in"`
 	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
@@ -71,13 +323,41 @@ type ParameterObject struct {
 	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
+func (p *ParameterObject) clone() *ParameterObject {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Schema = p.Schema.clone()
+	return &c
+}
+
 type RequestBodyObject struct {
+	Ref         string               `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	Description string               `json:"description,omitempty" yaml:"description,omitempty"`
 	Required    bool                 `json:"required,omitempty" yaml:"required,omitempty"`
 	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
 }
 
+func (b *RequestBodyObject) clone() *RequestBodyObject {
+	if b == nil {
+		return nil
+	}
+	c := *b
+	c.Content = cloneContent(b.Content)
+	return &c
+}
+
 type HeaderObject struct {
 	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
 	Schema      *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
 }
+
+func (h *HeaderObject) clone() *HeaderObject {
+	if h == nil {
+		return nil
+	}
+	c := *h
+	c.Schema = h.Schema.clone()
+	return &c
+}
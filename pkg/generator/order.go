@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+// Maps such as paths, responses and components are written with sorted keys by
+// both encoding/json and yaml, so only slices need an explicit order for the
+// spec to be byte-identical across runs and stable when code is moved around.
+
+// sortedRoutes returns the routes ordered by webhook, path and method, so that
+// operationIds and component names do not depend on file or declaration order
+func sortedRoutes(routes []parser.RouteDoc) []parser.RouteDoc {
+	sorted := slices.Clone(routes)
+	slices.SortStableFunc(sorted, func(a, b parser.RouteDoc) int {
+		return cmp.Or(
+			cmp.Compare(a.Webhook, b.Webhook),
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(methodOrder(a.Method), methodOrder(b.Method)),
+		)
+	})
+	return sorted
+}
+
+// methodOrder orders HTTP methods as PathItem does, with unknown methods last
+func methodOrder(method string) int {
+	if i := slices.Index(HTTPMethods, method); i >= 0 {
+		return i
+	}
+	return len(HTTPMethods)
+}
+
+var parameterLocations = []string{"path", "query", "header", "cookie"}
+
+// sortParameters orders parameters by location (path, query, header, cookie) and then by name
+func sortParameters(parameters []*ParameterObject) {
+	slices.SortStableFunc(parameters, func(a, b *ParameterObject) int {
+		return cmp.Or(
+			cmp.Compare(locationOrder(a.In), locationOrder(b.In)),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+}
+
+func locationOrder(in string) int {
+	if i := slices.Index(parameterLocations, in); i >= 0 {
+		return i
+	}
+	return len(parameterLocations)
+}
+
+// uniqueTags drops repeated tags, keeping the first occurrence of each
+func uniqueTags(tags []string) []string {
+	var unique []string
+	for _, tag := range tags {
+		if !slices.Contains(unique, tag) {
+			unique = append(unique, tag)
+		}
+	}
+	return unique
+}
+
+// specTags returns every tag used by an operation of a path or webhook, sorted by name
+func specTags(openapi *OpenAPI) []Tag {
+	names := make(map[string]bool)
+	for _, items := range []map[string]*PathItem{openapi.Paths, openapi.Webhooks} {
+		for _, item := range items {
+			for _, op := range item.Operations() {
+				for _, tag := range op.Tags {
+					names[tag] = true
+				}
+			}
+		}
+	}
+
+	var tags []Tag
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		tags = append(tags, Tag{Name: name})
+	}
+	return tags
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	oaparser "github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+// orderRoutes returns routes with models, tags, parameters and a webhook, in
+// the order a parser might report them
+func orderRoutes() []oaparser.RouteDoc {
+	return []oaparser.RouteDoc{
+		{
+			Method: "get", Path: "/users/{id}", Handler: "GetUser", Tags: []string{"users", "accounts"},
+			Params: []oaparser.Parameter{
+				{Name: "X-Request-ID", In: "header", Schema: "string"},
+				{Name: "fields", In: "query", Schema: "string"},
+				{Name: "id", In: "path", Schema: "string", Required: true},
+			},
+			Responses: map[string]oaparser.Response{
+				"404": {StatusCode: "404", Description: "Not found", Model: "example.com/app/api.Error", MediaType: "application/json"},
+				"200": {StatusCode: "200", Description: "OK", Model: "example.com/app/dto.User", MediaType: "application/json"},
+			},
+			Headers: []oaparser.Header{
+				{StatusCode: "200", Name: "X-RateLimit-Remaining", Type: "integer"},
+				{StatusCode: "200", Name: "X-RateLimit-Limit", Type: "integer"},
+			},
+		},
+		{
+			Method: "any", Path: "/health", Handler: "Health", Tags: []string{"ops"},
+			Responses: map[string]oaparser.Response{"200": {StatusCode: "200", Description: "OK"}},
+		},
+		{
+			Method: "post", Path: "/users", Handler: "CreateUser", Tags: []string{"users"},
+			RequestBody: &oaparser.RequestBody{Model: "example.com/app/models.User", MediaType: "application/json", Required: true},
+			Responses: map[string]oaparser.Response{
+				"201": {StatusCode: "201", Description: "Created", Model: "example.com/app/dto.User", MediaType: "application/json"},
+				"400": {StatusCode: "400", Description: "Bad request", Model: "example.com/app/api.Error", MediaType: "application/json"},
+			},
+			SecuritySchemes: []oaparser.SecurityScheme{{Name: "BearerAuth"}},
+		},
+		{
+			Method: "post", Webhook: "userCreated", Handler: "UserCreated", Tags: []string{"events"},
+			RequestBody: &oaparser.RequestBody{Model: "example.com/app/dto.User", MediaType: "application/json"},
+			Responses:   map[string]oaparser.Response{"200": {StatusCode: "200", Description: "OK"}},
+		},
+	}
+}
+
+func TestDeterministicOutput(t *testing.T) {
+	registry := sourceRegistry(t, map[string]string{
+		"example.com/app/api":    "package api\n\ntype Error struct{ Message string `json:\"message\"` }",
+		"example.com/app/dto":    "package dto\n\ntype User struct{ Name string `json:\"name\"`; Tags []string `json:\"tags\"` }",
+		"example.com/app/models": "package models\n\ntype User struct{ ID string `json:\"id\"` }",
+	})
+	metadata := oaparser.GlobalMetadata{GlobalTitle: "Users", GlobalVersion: "1.0.0"}
+
+	generate := func(routes []oaparser.RouteDoc, format string) []byte {
+		t.Helper()
+		spec, _, err := GenerateSpecWithOptions(routes, registry, metadata, Options{OpenAPIVersion: OpenAPIVersion31})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := MarshalSpec(spec, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		want := generate(orderRoutes(), format)
+		for seed := range uint64(20) {
+			routes := orderRoutes()
+			rng := rand.New(rand.NewPCG(seed, seed))
+			rng.Shuffle(len(routes), func(i, j int) { routes[i], routes[j] = routes[j], routes[i] })
+			for _, route := range routes {
+				rng.Shuffle(len(route.Params), func(i, j int) { route.Params[i], route.Params[j] = route.Params[j], route.Params[i] })
+				rng.Shuffle(len(route.Headers), func(i, j int) { route.Headers[i], route.Headers[j] = route.Headers[j], route.Headers[i] })
+			}
+
+			if got := generate(routes, format); !bytes.Equal(got, want) {
+				t.Fatalf("%s output with routes shuffled by seed %d differs:\n%s\nwant:\n%s", format, seed, got, want)
+			}
+		}
+	}
+}
+
+func TestSpecTagsIncludeWebhooks(t *testing.T) {
+	spec, _, err := GenerateSpecWithOptions(orderRoutes(), NewModelRegistry(), oaparser.GlobalMetadata{}, Options{OpenAPIVersion: OpenAPIVersion31})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, tag := range spec.Tags {
+		names = append(names, tag.Name)
+	}
+	if want := []string{"accounts", "events", "ops", "users"}; !slices.Equal(names, want) {
+		t.Errorf("tags = %v, want %v", names, want)
+	}
+}
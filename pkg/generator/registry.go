@@ -1,5 +1,14 @@
 package generator
 
+import (
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
 type ModelRegistry struct {
 	models map[string]any
 }
@@ -10,11 +19,101 @@ func NewModelRegistry() *ModelRegistry {
 	}
 }
 
+// NewModelRegistryFromSource builds a registry from the types declared in the
+// Go source below dir, so models do not have to be registered by hand.
+func NewModelRegistryFromSource(dir string) (*ModelRegistry, error) {
+	models, err := parser.DiscoverModels(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewModelRegistryFromTypes(models), nil
+}
+
+// NewModelRegistryFromSourceWithOptions is NewModelRegistryFromSource for the
+// packages selected by opts, as parser.DiscoverModelsWithOptions
+func NewModelRegistryFromSourceWithOptions(dir string, opts parser.Options) (*ModelRegistry, error) {
+	models, err := parser.DiscoverModelsWithOptions(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewModelRegistryFromTypes(models), nil
+}
+
+// NewModelRegistryFromTypes builds a registry from static Go types keyed by
+// model name, such as those returned by parser.DiscoverModels
+func NewModelRegistryFromTypes(models map[string]types.Type) *ModelRegistry {
+	registry := NewModelRegistry()
+	for name, t := range models {
+		registry.RegisterType(name, t)
+	}
+	return registry
+}
+
 func (r *ModelRegistry) Register(name string, model any) {
 	r.models[name] = model
 }
 
+// RegisterType registers a model by its static Go type instead of an instance
+func (r *ModelRegistry) RegisterType(name string, t types.Type) {
+	r.models[name] = t
+}
+
 func (r *ModelRegistry) Get(name string) (any, bool) {
 	m, ok := r.models[name]
 	return m, ok
 }
+
+// lookup returns the model registered as name and the name it is registered
+// under. Models detected in handler code are named by import path, so for
+// instances registered by hand with Register under their package-qualified or
+// bare name, those names are tried too. Types discovered from source are only
+// found by the name they are registered under, which is never ambiguous.
+func (r *ModelRegistry) lookup(name string) (string, any, bool) {
+	if m, ok := r.models[name]; ok {
+		return name, m, true
+	}
+
+	// "example.com/app/dto.User" is also tried as "dto.User" and "User"
+	slash := strings.LastIndex(name, "/")
+	dot := strings.LastIndex(name, ".")
+	if slash < 0 || dot < slash || strings.Contains(name, "[") {
+		return name, nil, false
+	}
+	for _, short := range []string{name[slash+1:], name[dot+1:]} {
+		if m, ok := r.models[short]; ok {
+			if _, isType := m.(types.Type); !isType {
+				return short, m, true
+			}
+		}
+	}
+	return name, nil, false
+}
+
+// ambiguousNames returns the registered names, bare or package-qualified, that
+// match the source types of several packages, with the import path qualified
+// names of those types. The import path qualified names registered by
+// parser.DiscoverModels are never ambiguous.
+func (r *ModelRegistry) ambiguousNames() map[string][]string {
+	matches := make(map[string]map[string]bool)
+	for _, model := range r.models {
+		named, ok := model.(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			continue
+		}
+		obj := named.Obj()
+		for _, name := range []string{obj.Name(), obj.Pkg().Name() + "." + obj.Name()} {
+			if matches[name] == nil {
+				matches[name] = make(map[string]bool)
+			}
+			matches[name][typeKey(named)] = true
+		}
+	}
+
+	ambiguous := make(map[string][]string)
+	for name, keys := range matches {
+		if _, registered := r.models[name]; registered && len(keys) > 1 {
+			ambiguous[name] = slices.Sorted(maps.Keys(keys))
+		}
+	}
+	return ambiguous
+}
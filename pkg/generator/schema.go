@@ -1,11 +1,40 @@
 package generator
 
 import (
+	"go/types"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 )
 
 func GenerateSchemaFromStruct(model any) *Schema {
+	return structSchema(model, nil)
+}
+
+// schemaOptions are the options of GenerateSpec that apply to the schemas of
+// models. A nil *schemaOptions is the default of GenerateSchemaFromStruct and
+// GenerateSchemaFromType.
+type schemaOptions struct {
+	names            *schemaNames
+	nullablePointers bool
+}
+
+// name returns the component name of t
+func (o *schemaOptions) name(t types.Type) string {
+	if o == nil {
+		return baseSchemaName(t)
+	}
+	return o.names.name(t)
+}
+
+// nullable reports whether a field is nullable, given whether it is a pointer
+func (o *schemaOptions) nullable(isPointer bool) bool {
+	return o != nil && o.nullablePointers && isPointer
+}
+
+// structSchema is GenerateSchemaFromStruct with the schema options of GenerateSpec
+func structSchema(model any, opts *schemaOptions) *Schema {
 	t := reflect.TypeOf(model)
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -40,14 +69,27 @@ func GenerateSchemaFromStruct(model any) *Schema {
 
 		var prop *Schema
 		if fieldType.Kind() == reflect.Struct && isCustomStruct(fieldType) {
-			// Create a reference to the nested struct
+			// Create a reference to the nested struct. The description is a
+			// sibling of the $ref, which only OpenAPI 3.1 keeps.
 			prop = &Schema{
-				Ref: "#/components/schemas/" + fieldType.Name(),
+				Ref:         "#/components/schemas/" + fieldType.Name(),
+				Description: desc,
 			}
 		} else {
 			prop = &Schema{
-				Type:        mapGoTypeToOpenAPIType(field.Type.Kind()),
+				Type:        mapGoTypeToOpenAPIType(fieldType.Kind()),
 				Description: desc,
+				Nullable:    opts.nullable(field.Type.Kind() == reflect.Ptr),
+			}
+			switch {
+			case isByteSlice(fieldType):
+				// encoding/json writes []byte as a base64 string
+				prop.Type = "string"
+			case fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array:
+				prop.Items = itemsSchema(fieldType.Elem())
+			}
+			if example, ok := extractTagOption(field.Tag.Get("openapi"), "example"); ok {
+				prop.Example = exampleValue(prop.Type, example)
 			}
 		}
 
@@ -57,6 +99,30 @@ func GenerateSchemaFromStruct(model any) *Schema {
 	return schema
 }
 
+// itemsSchema returns the schema of the elements of a slice or array, using a $ref for custom structs
+func itemsSchema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Struct && isCustomStruct(t) {
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+
+	items := &Schema{Type: mapGoTypeToOpenAPIType(t.Kind())}
+	switch {
+	case isByteSlice(t):
+		items.Type = "string"
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		items.Items = itemsSchema(t.Elem())
+	}
+	return items
+}
+
+func isByteSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
 func parseJSONName(tag string) string {
 	if idx := len(tag); idx > 0 {
 		if i := indexComma(tag); i >= 0 {
@@ -98,11 +164,66 @@ func mapGoTypeToOpenAPIType(kind reflect.Kind) string {
 
 func extractDescription(tag string) string {
 	// Example tag: openapi:"desc=User's name"
-	parts := strings.Split(tag, "desc=")
-	if len(parts) == 2 {
-		return strings.Trim(parts[1], `"`)
+	desc, _ := extractTagOption(tag, "desc")
+	return strings.Trim(desc, `"`)
+}
+
+// tagOptionKeys are the keys of the key=value options of an openapi tag
+var tagOptionKeys = []string{"desc", "example"}
+
+// splitTagOptions splits an openapi tag into its options. Options are separated
+// by semicolons: openapi:"desc=User's age;example=42". A semicolon that is not
+// followed by an option belongs to the value before it, so descriptions may
+// contain them: openapi:"desc=Age; see policy;example=42"
+func splitTagOptions(tag string) []string {
+	var options []string
+	for _, part := range strings.Split(tag, ";") {
+		if len(options) > 0 && !isTagOption(part) {
+			options[len(options)-1] += ";" + part
+			continue
+		}
+		options = append(options, part)
+	}
+	return options
+}
+
+// isTagOption reports whether s is an option of an openapi tag
+func isTagOption(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return true
+	}
+	key, _, ok := strings.Cut(s, "=")
+	return ok && slices.Contains(tagOptionKeys, key)
+}
+
+// extractTagOption returns the value of a key=value option of an openapi tag
+func extractTagOption(tag, key string) (string, bool) {
+	for _, option := range splitTagOptions(tag) {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(option), key+"="); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// exampleValue converts an example from a struct tag to the JSON type of its schema
+func exampleValue(schemaType, example string) any {
+	switch schemaType {
+	case "integer":
+		if v, err := strconv.ParseInt(example, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(example, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(example); err == nil {
+			return v
+		}
 	}
-	return ""
+	return example
 }
 
 // isCustomStruct checks if the type is a custom struct (not a built-in type)
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"testing"
+
+	oaparser "github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+func TestExtractTagOption(t *testing.T) {
+	tests := []struct {
+		tag, key string
+		want     string
+		wantOK   bool
+	}{
+		{"desc=User's name", "desc", "User's name", true},
+		{"desc=User's age;example=42", "desc", "User's age", true},
+		{"desc=User's age;example=42", "example", "42", true},
+		{"desc=Age; see the policy", "desc", "Age; see the policy", true},
+		{"desc=Age; see the policy;example=42", "desc", "Age; see the policy", true},
+		{"desc=Age; see the policy; example=42", "example", "42", true},
+		{"desc=Status; one of the below;example=active", "desc", "Status; one of the below", true},
+		{"example=a;b", "example", "a;b", true},
+		{"desc=Name;", "desc", "Name", true},
+		{"example=42", "desc", "", false},
+		{"", "desc", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+"/"+tt.key, func(t *testing.T) {
+			got, ok := extractTagOption(tt.tag, tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("extractTagOption(%q, %q) = %q, %v, want %q, %v", tt.tag, tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNullablePointers(t *testing.T) {
+	type Profile struct {
+		Nickname *string `json:"nickname"`
+		Age      int     `json:"age"`
+	}
+	registry := NewModelRegistry()
+	registry.Register("Profile", Profile{})
+
+	typed := sourceRegistry(t, map[string]string{
+		"example.com/app/dto": "package dto\n\ntype Profile struct {\n\tNickname *string `json:\"nickname\"`\n\tAge int `json:\"age\"`\n}",
+	})
+
+	for _, nullable := range []bool{false, true} {
+		for name, registry := range map[string]*ModelRegistry{"Struct": registry, "Type": typed} {
+			spec, _, err := GenerateSpecWithOptions(responseRoutes("Profile"), registry, oaparser.GlobalMetadata{}, Options{NullablePointers: nullable})
+			if err != nil {
+				t.Fatal(err)
+			}
+			props := spec.Components.Schemas["Profile"].Properties
+			if got := props["nickname"].Nullable; got != nullable {
+				t.Errorf("%s: nickname nullable = %v with NullablePointers %v", name, got, nullable)
+			}
+			if props["age"].Nullable {
+				t.Errorf("%s: age is nullable with NullablePointers %v", name, nullable)
+			}
+		}
+	}
+}
+
+func TestPointerFieldTypes(t *testing.T) {
+	type Profile struct {
+		Age      *int      `json:"age"`
+		Score    *float64  `json:"score"`
+		Active   *bool     `json:"active"`
+		Nickname *string   `json:"nickname"`
+		Tags     *[]string `json:"tags"`
+	}
+
+	props := GenerateSchemaFromStruct(Profile{}).Properties
+	want := map[string]string{"age": "integer", "score": "number", "active": "boolean", "nickname": "string", "tags": "array"}
+	for name, typ := range want {
+		if got := props[name].Type; got != typ {
+			t.Errorf("%s type = %q, want %q", name, got, typ)
+		}
+	}
+	if items := props["tags"].Items; items == nil || items.Type != "string" {
+		t.Errorf("tags items = %+v, want a string schema", items)
+	}
+}
+
+func TestStructSchemaItems(t *testing.T) {
+	type Friend struct {
+		Name string `json:"name"`
+	}
+	type Profile struct {
+		Tags    []string  `json:"tags"`
+		Friends []*Friend `json:"friends"`
+		Matrix  [][2]int  `json:"matrix"`
+		Avatar  []byte    `json:"avatar"`
+	}
+	registry := NewModelRegistry()
+	registry.Register("Profile", Profile{})
+
+	metadata := oaparser.GlobalMetadata{GlobalTitle: "Profiles", GlobalVersion: "1.0.0"}
+	spec, _, err := GenerateSpecWithOptions(responseRoutes("Profile"), registry, metadata, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := Validate(spec); len(errs) > 0 {
+		t.Errorf("Validate() = %v, want no errors", errs)
+	}
+
+	props := spec.Components.Schemas["Profile"].Properties
+	if items := props["tags"].Items; items == nil || items.Type != "string" {
+		t.Errorf("tags items = %+v, want a string schema", items)
+	}
+	if items := props["friends"].Items; items == nil || items.Ref != "#/components/schemas/Friend" {
+		t.Errorf("friends items = %+v, want a $ref to Friend", items)
+	}
+	if spec.Components.Schemas["Friend"] == nil {
+		t.Error("Friend is not registered as a component schema")
+	}
+	if items := props["matrix"].Items; items == nil || items.Items == nil || items.Items.Type != "integer" {
+		t.Errorf("matrix items = %+v, want arrays of integers", items)
+	}
+	if avatar := props["avatar"]; avatar.Type != "string" || avatar.Items != nil {
+		t.Errorf("avatar = %+v, want a string schema", avatar)
+	}
+}
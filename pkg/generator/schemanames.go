@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"go/types"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+// schemaNames are the component names of the named types used by a spec.
+// A type is named by its bare type name, followed by the type arguments of a
+// generic instantiation ("Page_User"). When types of different packages would
+// share a name, each is qualified by its package name ("dto_User"), or by its
+// import path when the package names are shared too.
+// A nil *schemaNames names every type by its bare name.
+type schemaNames struct {
+	names map[string]string // by typeKey
+}
+
+// newSchemaNames names the custom types reachable from models, reporting the
+// names that had to be qualified
+func newSchemaNames(models []types.Type, diags *parser.Diagnostics) *schemaNames {
+	reachable := make(map[string]types.Type)
+	var visit func(t types.Type)
+	visit = func(t types.Type) {
+		t = derefType(t)
+		key := typeKey(t)
+		if _, seen := reachable[key]; seen {
+			return
+		}
+		reachable[key] = t
+
+		if st, ok := t.Underlying().(*types.Struct); ok {
+			for i := 0; i < st.NumFields(); i++ {
+				for _, nested := range nestedNamedStructs(st.Field(i).Type()) {
+					visit(nested)
+				}
+			}
+		}
+	}
+	for _, t := range models {
+		visit(t)
+	}
+
+	byName := make(map[string][]string)
+	for key, t := range reachable {
+		name := baseSchemaName(t)
+		byName[name] = append(byName[name], key)
+	}
+
+	n := &schemaNames{names: make(map[string]string, len(reachable))}
+	taken := make(map[string]bool)
+	var collisions []string
+	for _, name := range slices.Sorted(maps.Keys(byName)) {
+		if keys := byName[name]; len(keys) == 1 {
+			n.names[keys[0]] = name
+			taken[name] = true
+		} else {
+			collisions = append(collisions, name)
+		}
+	}
+
+	for _, name := range collisions {
+		keys := byName[name]
+		slices.Sort(keys)
+
+		qualified := qualifySchemaNames(name, keys, reachable)
+		for i, key := range keys {
+			unique := qualified[i]
+			for suffix := 2; taken[unique]; suffix++ {
+				unique = qualified[i] + strconv.Itoa(suffix)
+			}
+			n.names[key] = unique
+			taken[unique] = true
+			qualified[i] = unique
+		}
+
+		diags.Warnf(parser.CodeSchemaNameCollision, "", pointer("/components/schemas", name),
+			"types %s share the component name %s, naming them %s", strings.Join(keys, ", "), name, strings.Join(qualified, ", "))
+	}
+
+	return n
+}
+
+// qualifySchemaNames prefixes name with the package names of the types keys,
+// or with their import paths if the package names do not tell them apart
+func qualifySchemaNames(name string, keys []string, reachable map[string]types.Type) []string {
+	byPackageName := make([]string, len(keys))
+	byPath := make([]string, len(keys))
+	seen := make(map[string]bool)
+	distinct := true
+	for i, key := range keys {
+		byPackageName[i], byPath[i] = name, name
+		if named, ok := reachable[key].(*types.Named); ok && named.Obj().Pkg() != nil {
+			pkg := named.Obj().Pkg()
+			byPackageName[i] = pkg.Name() + "_" + name
+			byPath[i] = sanitizeSchemaName(pkg.Path()) + "_" + name
+		}
+		distinct = distinct && !seen[byPackageName[i]]
+		seen[byPackageName[i]] = true
+	}
+
+	if distinct {
+		return byPackageName
+	}
+	return byPath
+}
+
+// name returns the component name of t
+func (n *schemaNames) name(t types.Type) string {
+	if n != nil {
+		if name, ok := n.names[typeKey(t)]; ok {
+			return name
+		}
+	}
+	return baseSchemaName(t)
+}
+
+// typeKey identifies a type by its import path qualified name, including type arguments
+func typeKey(t types.Type) string {
+	return types.TypeString(derefType(t), nil)
+}
+
+// baseSchemaName returns the unqualified component name of t
+func baseSchemaName(t types.Type) string {
+	t = derefType(t)
+	named, ok := t.(*types.Named)
+	if !ok {
+		return typeArgName(t)
+	}
+
+	name := named.Obj().Name()
+	args := named.TypeArgs()
+	for i := 0; i < args.Len(); i++ {
+		name += "_" + typeArgName(args.At(i))
+	}
+	return name
+}
+
+// typeArgName returns the part of a component name standing for the type argument t
+func typeArgName(t types.Type) string {
+	switch u := derefType(t).(type) {
+	case *types.Named:
+		return baseSchemaName(u)
+	case *types.Slice:
+		return typeArgName(u.Elem()) + "List"
+	case *types.Array:
+		return typeArgName(u.Elem()) + "List"
+	case *types.Map:
+		return "Map_" + typeArgName(u.Key()) + "_" + typeArgName(u.Elem())
+	}
+	return sanitizeSchemaName(types.TypeString(t, (*types.Package).Name))
+}
+
+// sanitizeSchemaName replaces the characters not allowed in component names by underscores
+func sanitizeSchemaName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+	return strings.Trim(name, "_")
+}
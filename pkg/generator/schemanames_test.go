@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"testing"
+
+	oaparser "github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+// sourceImporter type-checks the packages given as source, keyed by import path
+type sourceImporter struct {
+	fset    *token.FileSet
+	sources map[string]string
+	checked map[string]*types.Package
+}
+
+func (im *sourceImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := im.checked[path]; ok {
+		return pkg, nil
+	}
+	file, err := parser.ParseFile(im.fset, path+"/src.go", im.sources[path], 0)
+	if err != nil {
+		return nil, err
+	}
+	conf := types.Config{Importer: im}
+	pkg, err := conf.Check(path, im.fset, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, err
+	}
+	im.checked[path] = pkg
+	return pkg, nil
+}
+
+// sourceRegistry type-checks sources and registers their package level types
+// under the names parser.DiscoverModels uses
+func sourceRegistry(t *testing.T, sources map[string]string) *ModelRegistry {
+	t.Helper()
+
+	im := &sourceImporter{fset: token.NewFileSet(), sources: sources, checked: make(map[string]*types.Package)}
+	registry := NewModelRegistry()
+	for _, path := range slices.Sorted(maps.Keys(sources)) {
+		pkg, err := im.Import(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range pkg.Scope().Names() {
+			obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			for _, key := range []string{pkg.Path() + "." + name, pkg.Name() + "." + name, name} {
+				if _, exists := registry.Get(key); !exists {
+					registry.RegisterType(key, obj.Type())
+				}
+			}
+		}
+	}
+	return registry
+}
+
+// responseRoutes returns one GET route per model, responding with it
+func responseRoutes(models ...string) []oaparser.RouteDoc {
+	var routes []oaparser.RouteDoc
+	for i, model := range models {
+		routes = append(routes, oaparser.RouteDoc{
+			Method:  "GET",
+			Path:    "/" + string(rune('a'+i)),
+			Handler: "Get" + string(rune('A'+i)),
+			Responses: map[string]oaparser.Response{
+				"200": {Model: model, MediaType: "application/json"},
+			},
+		})
+	}
+	return routes
+}
+
+func diagnosticCodes(diags oaparser.Diagnostics) []string {
+	var codes []string
+	for _, d := range diags {
+		codes = append(codes, d.Code)
+	}
+	return codes
+}
+
+func TestSchemaNames(t *testing.T) {
+	sources := map[string]string{
+		"example.com/app/dto":       "package dto\n\ntype User struct{ Name string `json:\"name\"` }\ntype Order struct{ ID string `json:\"id\"` }",
+		"example.com/app/models":    "package models\n\ntype User struct{ ID string `json:\"id\"` }",
+		"example.com/app/admin/dto": "package dto\n\ntype User struct{ Role string `json:\"role\"` }",
+		"example.com/app/page": `package page
+
+import (
+	"example.com/app/dto"
+	"example.com/app/models"
+)
+
+type Page[T any] struct {
+	Items []T ` + "`json:\"items\"`" + `
+}
+
+type Users struct {
+	Page Page[dto.User] ` + "`json:\"page\"`" + `
+	Owner models.User ` + "`json:\"owner\"`" + `
+}
+
+type Orders struct {
+	Page Page[dto.Order] ` + "`json:\"page\"`" + `
+}
+`,
+	}
+	registry := sourceRegistry(t, sources)
+
+	tests := []struct {
+		name      string
+		models    []string
+		want      []string // component names
+		wantRefs  []string // $ref of each response
+		wantCodes []string
+	}{
+		{
+			name:     "NoCollision",
+			models:   []string{"example.com/app/dto.User", "example.com/app/dto.Order"},
+			want:     []string{"Order", "User"},
+			wantRefs: []string{"User", "Order"},
+		},
+		{
+			name:      "PackageName",
+			models:    []string{"example.com/app/dto.User", "example.com/app/models.User"},
+			want:      []string{"dto_User", "models_User"},
+			wantRefs:  []string{"dto_User", "models_User"},
+			wantCodes: []string{oaparser.CodeSchemaNameCollision},
+		},
+		{
+			name:      "ImportPath",
+			models:    []string{"example.com/app/dto.User", "example.com/app/admin/dto.User"},
+			want:      []string{"example.com_app_admin_dto_User", "example.com_app_dto_User"},
+			wantRefs:  []string{"example.com_app_dto_User", "example.com_app_admin_dto_User"},
+			wantCodes: []string{oaparser.CodeSchemaNameCollision},
+		},
+		{
+			name:      "Nested",
+			models:    []string{"example.com/app/page.Users", "example.com/app/dto.User"},
+			want:      []string{"Page_User", "Users", "dto_User", "models_User"},
+			wantRefs:  []string{"Users", "dto_User"},
+			wantCodes: []string{oaparser.CodeSchemaNameCollision},
+		},
+		{
+			name:      "GenericInstantiations",
+			models:    []string{"example.com/app/page.Users", "example.com/app/page.Orders"},
+			want:      []string{"Order", "Orders", "Page_Order", "Page_User", "Users", "dto_User", "models_User"},
+			wantRefs:  []string{"Users", "Orders"},
+			wantCodes: []string{oaparser.CodeSchemaNameCollision},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, diags, err := GenerateSpecWithOptions(responseRoutes(tt.models...), registry, oaparser.GlobalMetadata{}, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := slices.Sorted(maps.Keys(spec.Components.Schemas)); !slices.Equal(got, tt.want) {
+				t.Errorf("component schemas = %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.wantRefs {
+				path := "/" + string(rune('a'+i))
+				got := spec.Paths[path].Get.Responses["200"].Content["application/json"].Schema.Ref
+				if got != "#/components/schemas/"+want {
+					t.Errorf("%s response $ref = %s, want %s", path, got, want)
+				}
+			}
+			if got := diagnosticCodes(diags); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("diagnostics = %v, want codes %v", diags, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestSchemaNamesNestedRefs(t *testing.T) {
+	registry := sourceRegistry(t, map[string]string{
+		"example.com/app/dto":    "package dto\n\ntype User struct{ Name string `json:\"name\"` }",
+		"example.com/app/models": "package models\n\nimport \"example.com/app/dto\"\n\ntype User struct{ Profile dto.User `json:\"profile\"` }",
+	})
+
+	spec, _, err := GenerateSpecWithOptions(responseRoutes("example.com/app/models.User"), registry, oaparser.GlobalMetadata{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile := spec.Components.Schemas["models_User"].Properties["profile"]
+	if profile == nil || profile.Ref != "#/components/schemas/dto_User" {
+		t.Errorf("profile = %+v, want a $ref to dto_User", profile)
+	}
+	if spec.Components.Schemas["dto_User"].Properties["name"] == nil {
+		t.Errorf("dto_User = %+v, want the schema of dto.User", spec.Components.Schemas["dto_User"])
+	}
+}
+
+func TestAmbiguousModel(t *testing.T) {
+	registry := sourceRegistry(t, map[string]string{
+		"example.com/app/dto":       "package dto\n\ntype User struct{ Name string `json:\"name\"` }",
+		"example.com/app/models":    "package models\n\ntype User struct{ ID string `json:\"id\"` }",
+		"example.com/app/admin/dto": "package dto\n\ntype User struct{ Role string `json:\"role\"` }",
+	})
+
+	tests := []struct {
+		model     string
+		wantCodes []string
+	}{
+		{"User", []string{oaparser.CodeAmbiguousModel}},
+		{"dto.User", []string{oaparser.CodeAmbiguousModel}},
+		{"models.User", nil},
+		{"example.com/app/dto.User", nil},
+		{"example.com/app/models.User", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			_, diags, err := GenerateSpecWithOptions(responseRoutes(tt.model), registry, oaparser.GlobalMetadata{}, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := diagnosticCodes(diags); !slices.Equal(got, tt.wantCodes) {
+				t.Errorf("diagnostics = %v, want codes %v", diags, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestDetectedModelCollision(t *testing.T) {
+	registry := sourceRegistry(t, map[string]string{
+		"example.com/app/api":   "package api\n\ntype User struct{ Name string `json:\"name\"` }",
+		"example.com/app/store": "package store\n\ntype User struct{ ID string `json:\"id\"` }",
+	})
+
+	// Models detected in handler code are named by import path, as the parser reports them
+	routes := responseRoutes("example.com/app/store.User")
+	routes[0].RequestBody = &oaparser.RequestBody{Model: "example.com/app/api.User", MediaType: "application/json"}
+	spec, diags, err := GenerateSpecWithOptions(routes, registry, oaparser.GlobalMetadata{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 1 || diags[0].Code != oaparser.CodeSchemaNameCollision {
+		t.Errorf("diagnostics = %v, want only a %s", diags, oaparser.CodeSchemaNameCollision)
+	}
+
+	op := spec.Paths["/a"].Get
+	if ref := op.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/store_User" {
+		t.Errorf("response $ref = %s, want store_User", ref)
+	}
+	if ref := op.RequestBody.Content["application/json"].Schema.Ref; ref != "#/components/schemas/api_User" {
+		t.Errorf("request body $ref = %s, want api_User", ref)
+	}
+	if spec.Components.Schemas["store_User"].Properties["id"] == nil {
+		t.Errorf("store_User = %+v, want the schema of store.User", spec.Components.Schemas["store_User"])
+	}
+}
+
+func TestDetectedModelRegisteredByName(t *testing.T) {
+	type User struct {
+		ID string `json:"id"`
+	}
+	registry := NewModelRegistry()
+	registry.Register("User", User{})
+
+	spec, diags, err := GenerateSpecWithOptions(responseRoutes("example.com/app/store.User"), registry, oaparser.GlobalMetadata{}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) > 0 {
+		t.Errorf("diagnostics = %v, want none", diags)
+	}
+	if ref := spec.Paths["/a"].Get.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/User" {
+		t.Errorf("response $ref = %s, want the User registered by hand", ref)
+	}
+}
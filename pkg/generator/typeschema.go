@@ -0,0 +1,212 @@
+package generator
+
+import (
+	"go/types"
+	"reflect"
+)
+
+// GenerateSchemaFromType builds a schema from a statically discovered Go type.
+// It is the go/types counterpart of GenerateSchemaFromStruct and follows the
+// same json/openapi tag rules, so that models found in source produce the same
+// schema as models registered at runtime.
+//
+// Nested structs are referenced by their bare type name; GenerateSpec qualifies
+// the names shared by types of different packages.
+func GenerateSchemaFromType(t types.Type) *Schema {
+	return typeSchema(t, nil)
+}
+
+// typeSchema is GenerateSchemaFromType with the schema options of GenerateSpec
+func typeSchema(t types.Type, opts *schemaOptions) *Schema {
+	t = derefType(t)
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return typePropertySchema(t, "", opts)
+	}
+
+	schema := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+	addStructProperties(st, schema, opts)
+
+	return schema
+}
+
+func addStructProperties(st *types.Struct, schema *Schema, opts *schemaOptions) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i))
+		jsonName := tag.Get("json")
+
+		// Embedded structs without a json name are flattened, just like encoding/json does
+		if field.Embedded() && jsonName == "" {
+			if embedded, ok := derefType(field.Type()).Underlying().(*types.Struct); ok {
+				addStructProperties(embedded, schema, opts)
+			}
+			continue
+		}
+
+		if !field.Exported() || jsonName == "" || jsonName == "-" {
+			continue
+		}
+
+		// Remove ,omitempty etc.
+		jsonName = parseJSONName(jsonName)
+
+		prop := typePropertySchema(field.Type(), extractDescription(tag.Get("openapi")), opts)
+		if example, ok := extractTagOption(tag.Get("openapi"), "example"); ok && prop.Ref == "" {
+			prop.Example = exampleValue(prop.Type, example)
+		}
+		schema.Properties[jsonName] = prop
+	}
+}
+
+// typePropertySchema returns the schema for a field type, using a $ref for custom structs.
+// Pointers are nullable if opts asks for it.
+func typePropertySchema(t types.Type, desc string, opts *schemaOptions) *Schema {
+	_, isPointer := t.(*types.Pointer)
+	t = derefType(t)
+
+	if isCustomNamedStruct(t) {
+		// The description is a sibling of the $ref, which only OpenAPI 3.1 keeps
+		return &Schema{
+			Ref:         "#/components/schemas/" + opts.name(t),
+			Description: desc,
+		}
+	}
+
+	prop := &Schema{
+		Type:        mapTypeToOpenAPIType(t),
+		Description: desc,
+		Nullable:    opts.nullable(isPointer),
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		if isByteType(u.Elem()) {
+			prop.Type = "string"
+		} else {
+			prop.Items = typePropertySchema(u.Elem(), "", opts)
+		}
+	case *types.Array:
+		prop.Items = typePropertySchema(u.Elem(), "", opts)
+	}
+
+	return prop
+}
+
+func mapTypeToOpenAPIType(t types.Type) string {
+	if isTimeType(t) {
+		return "string"
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			return "boolean"
+		case info&types.IsInteger != 0:
+			return "integer"
+		case info&types.IsFloat != 0:
+			return "number"
+		default:
+			return "string"
+		}
+	case *types.Slice, *types.Array:
+		return "array"
+	case *types.Map, *types.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+// registerNestedTypeSchemas recursively registers schemas for custom structs reachable from t
+func registerNestedTypeSchemas(t types.Type, components *Components, opts *schemaOptions) {
+	st, ok := derefType(t).Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		for _, nested := range nestedNamedStructs(st.Field(i).Type()) {
+			schemaName := opts.name(nested)
+
+			// If not already registered, register it
+			if _, exists := components.Schemas[schemaName]; !exists {
+				components.Schemas[schemaName] = typeSchema(nested, opts)
+
+				// Recursively register nested structs
+				registerNestedTypeSchemas(nested, components, opts)
+			}
+		}
+	}
+}
+
+// nestedNamedStructs returns the custom structs referenced by a field type,
+// looking through pointers, slices, arrays and map values.
+func nestedNamedStructs(t types.Type) []types.Type {
+	t = derefType(t)
+
+	if isCustomNamedStruct(t) {
+		return []types.Type{t}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return nestedNamedStructs(u.Elem())
+	case *types.Array:
+		return nestedNamedStructs(u.Elem())
+	case *types.Map:
+		return nestedNamedStructs(u.Elem())
+	case *types.Struct:
+		// Anonymous or embedded structs: their fields may still reference custom structs
+		var nested []types.Type
+		for i := 0; i < u.NumFields(); i++ {
+			nested = append(nested, nestedNamedStructs(u.Field(i).Type())...)
+		}
+		return nested
+	}
+
+	return nil
+}
+
+func derefType(t types.Type) types.Type {
+	for {
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			return t
+		}
+		t = ptr.Elem()
+	}
+}
+
+// isCustomNamedStruct is the go/types counterpart of isCustomStruct
+func isCustomNamedStruct(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return false
+	}
+
+	return named.Obj().Pkg() != nil && !isTimeType(t)
+}
+
+func isTimeType(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+func isByteType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
@@ -0,0 +1,612 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ValidationError is a structural problem of a spec, located by a JSON pointer
+type ValidationError struct {
+	Pointer string `json:"pointer"` // RFC 6901 pointer into the document, e.g. /paths/~1users~1{id}/get
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return e.Pointer + ": " + e.Message
+}
+
+var (
+	openAPIVersionPattern = regexp.MustCompile(`^3\.[01]\.\d+$`)
+	responseCodePattern   = regexp.MustCompile(`^[1-5](\d\d|XX)$`)
+	pathTemplatePattern   = regexp.MustCompile(`\{([^{}/]*)\}`)
+)
+
+var (
+	schemaTypes         = []string{"string", "number", "integer", "boolean", "array", "object"}
+	securitySchemeTypes = []string{"apiKey", "http", "oauth2", "openIdConnect"}
+)
+
+// Validate checks openapi against the structural rules of OpenAPI 3.0 and 3.1:
+// required fields are present, local $refs resolve, path templates match the
+// declared path parameters, operationIds are unique and response codes are
+// valid. Keywords of the other version, such as nullable in 3.1 or webhooks in
+// 3.0, are reported too. Problems are returned in document order.
+func Validate(openapi *OpenAPI) []ValidationError {
+	v := &validator{
+		openapi:      openapi,
+		is31:         strings.HasPrefix(openapi.OpenAPI, "3.1."),
+		operationIDs: make(map[string]string),
+	}
+	v.validate()
+	return v.errors
+}
+
+type validator struct {
+	openapi      *OpenAPI
+	is31         bool
+	skipRefs     bool              // $refs are resolved against the decoded document instead, by ValidateSpec
+	operationIDs map[string]string // operationId to the pointer of the operation using it first
+	errors       []ValidationError
+}
+
+func (v *validator) errorf(pointer, format string, args ...any) {
+	v.errors = append(v.errors, ValidationError{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+// pointer appends reference tokens to a JSON pointer, escaping ~ and /
+func pointer(base string, tokens ...any) string {
+	for _, token := range tokens {
+		escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(token))
+		base += "/" + escaped
+	}
+	return base
+}
+
+func (v *validator) validate() {
+	openapi := v.openapi
+
+	switch {
+	case openapi.OpenAPI == "":
+		v.errorf("/openapi", "openapi version is required")
+	case !openAPIVersionPattern.MatchString(openapi.OpenAPI):
+		v.errorf("/openapi", "unsupported OpenAPI version %q, expected 3.0.x or 3.1.x", openapi.OpenAPI)
+	}
+
+	v.validateInfo()
+
+	for i, server := range openapi.Servers {
+		if server.URL == "" {
+			v.errorf(pointer("/servers", i, "url"), "server url is required")
+		}
+	}
+
+	if openapi.Paths == nil && !v.is31 {
+		v.errorf("/paths", "paths is required")
+	}
+	if v.is31 && len(openapi.Paths) == 0 && len(openapi.Webhooks) == 0 && openapi.Components == nil {
+		v.errorf("", "at least one of paths, webhooks or components is required")
+	}
+
+	templates := make(map[string]string)
+	for _, path := range slices.Sorted(maps.Keys(openapi.Paths)) {
+		ptr := pointer("/paths", path)
+		if !strings.HasPrefix(path, "/") {
+			v.errorf(ptr, "path %q must begin with /", path)
+		}
+
+		// /users/{id} and /users/{name} are the same path
+		template := pathTemplatePattern.ReplaceAllString(path, "{}")
+		if other, ok := templates[template]; ok {
+			v.errorf(ptr, "path %q is identical to %q apart from parameter names", path, other)
+		}
+		templates[template] = path
+
+		v.validatePathItem(ptr, path, openapi.Paths[path])
+	}
+
+	if len(openapi.Webhooks) > 0 && !v.is31 {
+		v.errorf("/webhooks", "webhooks require OpenAPI 3.1")
+	}
+	for _, name := range slices.Sorted(maps.Keys(openapi.Webhooks)) {
+		v.validatePathItem(pointer("/webhooks", name), "", openapi.Webhooks[name])
+	}
+
+	v.validateComponents()
+
+	seenTags := make(map[string]bool)
+	for i, tag := range openapi.Tags {
+		switch {
+		case tag.Name == "":
+			v.errorf(pointer("/tags", i, "name"), "tag name is required")
+		case seenTags[tag.Name]:
+			v.errorf(pointer("/tags", i, "name"), "duplicate tag %q", tag.Name)
+		}
+		seenTags[tag.Name] = true
+	}
+}
+
+func (v *validator) validateInfo() {
+	info := v.openapi.Info
+	if info.Title == "" {
+		v.errorf("/info/title", "info title is required")
+	}
+	if info.Version == "" {
+		v.errorf("/info/version", "info version is required")
+	}
+
+	if license := info.License; license != nil {
+		if license.Name == "" {
+			v.errorf("/info/license/name", "license name is required")
+		}
+		if license.Identifier != "" && !v.is31 {
+			v.errorf("/info/license/identifier", "license identifier requires OpenAPI 3.1")
+		}
+		if license.Identifier != "" && license.URL != "" {
+			v.errorf("/info/license", "license identifier and url are mutually exclusive")
+		}
+	}
+}
+
+// validatePathItem validates the operations of a path item. Webhooks have no path.
+func (v *validator) validatePathItem(ptr, path string, item *PathItem) {
+	if item == nil {
+		return
+	}
+
+	v.validateParameters(pointer(ptr, "parameters"), item.Parameters)
+	if path != "" {
+		v.validatePathTemplate(ptr, path, item.Parameters)
+	}
+
+	operations := item.Operations()
+	for _, method := range HTTPMethods {
+		op, ok := operations[method]
+		if !ok {
+			continue
+		}
+		opPtr := pointer(ptr, method)
+
+		if op.OperationID != "" {
+			if first, ok := v.operationIDs[op.OperationID]; ok {
+				v.errorf(pointer(opPtr, "operationId"), "duplicate operationId %q, also used by %s", op.OperationID, first)
+			} else {
+				v.operationIDs[op.OperationID] = opPtr
+			}
+		}
+
+		v.validateParameters(pointer(opPtr, "parameters"), op.Parameters)
+		if path != "" {
+			v.validatePathParameters(ptr, method, path, item.Parameters, op.Parameters)
+		}
+
+		if body := op.RequestBody; body != nil {
+			bodyPtr := pointer(opPtr, "requestBody")
+			if body.Ref != "" {
+				v.validateRef(pointer(bodyPtr, "$ref"), body.Ref)
+			} else {
+				v.validateContent(bodyPtr, body.Content, true)
+			}
+		}
+
+		if len(op.Responses) == 0 && !v.is31 {
+			v.errorf(pointer(opPtr, "responses"), "at least one response is required")
+		}
+		for _, code := range slices.Sorted(maps.Keys(op.Responses)) {
+			respPtr := pointer(opPtr, "responses", code)
+			if code != "default" && !responseCodePattern.MatchString(code) {
+				v.errorf(respPtr, "invalid response code %q, expected default, a status code or a range like 4XX", code)
+			}
+			v.validateResponse(respPtr, op.Responses[code])
+		}
+
+		for i, requirement := range op.Security {
+			for _, name := range slices.Sorted(maps.Keys(requirement)) {
+				if v.openapi.Components == nil || v.openapi.Components.SecuritySchemes[name] == nil {
+					v.errorf(pointer(opPtr, "security", i, name), "security scheme %q is not defined in components", name)
+				}
+			}
+		}
+	}
+}
+
+// validatePathParameters checks that every template expression of path is
+// declared as a path parameter of the operation or its path item, and every
+// path parameter of the operation appears in path
+func (v *validator) validatePathParameters(itemPtr, method, path string, shared, own []*ParameterObject) {
+	opPtr := pointer(itemPtr, method)
+	templateNames := pathTemplateNames(path)
+
+	declared := make(map[string]bool)
+	for _, param := range shared {
+		if param = resolveParameter(v.openapi, param); param != nil && param.In == "path" {
+			declared[param.Name] = true
+		}
+	}
+	for i, param := range own {
+		if param = resolveParameter(v.openapi, param); param == nil || param.In != "path" {
+			continue
+		}
+		declared[param.Name] = true
+		if !slices.Contains(templateNames, param.Name) {
+			v.errorf(pointer(opPtr, "parameters", i), "path parameter %q does not appear in path %q", param.Name, path)
+		}
+	}
+
+	for _, name := range templateNames {
+		if !declared[name] {
+			v.errorf(opPtr, "path parameter %q of path %q is not declared", name, path)
+		}
+	}
+}
+
+// validatePathTemplate checks the template expressions of path and the path
+// parameters shared by all operations of its path item
+func (v *validator) validatePathTemplate(itemPtr, path string, shared []*ParameterObject) {
+	templateNames := pathTemplateNames(path)
+	if strings.Count(path, "{") != len(templateNames) || strings.Count(path, "}") != len(templateNames) {
+		v.errorf(itemPtr, "malformed path template %q", path)
+	}
+
+	for i, param := range shared {
+		if param = resolveParameter(v.openapi, param); param != nil && param.In == "path" && !slices.Contains(templateNames, param.Name) {
+			v.errorf(pointer(itemPtr, "parameters", i), "path parameter %q does not appear in path %q", param.Name, path)
+		}
+	}
+}
+
+// pathTemplateNames returns the names of the template expressions of path, e.g. id for /users/{id}
+func pathTemplateNames(path string) []string {
+	var names []string
+	for _, match := range pathTemplatePattern.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+func (v *validator) validateParameters(ptr string, parameters []*ParameterObject) {
+	seen := make(map[string]bool)
+	for i, param := range parameters {
+		paramPtr := pointer(ptr, i)
+		if param == nil {
+			v.errorf(paramPtr, "parameter must be an object")
+			continue
+		}
+		if param.Ref != "" {
+			v.validateRef(pointer(paramPtr, "$ref"), param.Ref)
+			continue
+		}
+		v.validateParameter(paramPtr, param)
+
+		key := param.In + ":" + param.Name
+		if seen[key] {
+			v.errorf(paramPtr, "duplicate %s parameter %q", param.In, param.Name)
+		}
+		seen[key] = true
+	}
+}
+
+func (v *validator) validateParameter(ptr string, param *ParameterObject) {
+	if param.Name == "" {
+		v.errorf(pointer(ptr, "name"), "parameter name is required")
+	}
+
+	switch param.In {
+	case "":
+		v.errorf(pointer(ptr, "in"), "parameter location is required")
+	case "path":
+		if !param.Required {
+			v.errorf(pointer(ptr, "required"), "path parameter %q must be required", param.Name)
+		}
+	case "query", "header", "cookie":
+	default:
+		v.errorf(pointer(ptr, "in"), "invalid parameter location %q, expected path, query, header or cookie", param.In)
+	}
+
+	v.validateSchema(pointer(ptr, "schema"), param.Schema)
+}
+
+func (v *validator) validateResponse(ptr string, resp *ResponseWrapper) {
+	if resp == nil {
+		v.errorf(ptr, "response must be an object")
+		return
+	}
+	if resp.Ref != "" {
+		v.validateRef(pointer(ptr, "$ref"), resp.Ref)
+		return
+	}
+
+	if resp.Description == "" {
+		v.errorf(pointer(ptr, "description"), "response description is required")
+	}
+	v.validateContent(ptr, resp.Content, false)
+	for _, name := range slices.Sorted(maps.Keys(resp.Headers)) {
+		if header := resp.Headers[name]; header != nil {
+			v.validateSchema(pointer(ptr, "headers", name, "schema"), header.Schema)
+		}
+	}
+}
+
+func (v *validator) validateContent(ptr string, content map[string]MediaType, required bool) {
+	if required && len(content) == 0 {
+		v.errorf(pointer(ptr, "content"), "content is required")
+	}
+	for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+		v.validateSchema(pointer(ptr, "content", mediaType, "schema"), content[mediaType].Schema)
+	}
+}
+
+func (v *validator) validateSchema(ptr string, s *Schema) {
+	if s == nil {
+		return
+	}
+
+	if s.Ref != "" {
+		v.validateRef(pointer(ptr, "$ref"), s.Ref)
+	}
+
+	if s.Type != "" && !slices.Contains(schemaTypes, s.Type) && !(v.is31 && s.Type == "null") {
+		v.errorf(pointer(ptr, "type"), "invalid schema type %q", s.Type)
+	}
+	if len(s.Types) > 0 {
+		if !v.is31 {
+			v.errorf(pointer(ptr, "type"), "type lists require OpenAPI 3.1, use nullable in 3.0")
+		}
+		for i, t := range s.Types {
+			if !slices.Contains(schemaTypes, t) && t != "null" {
+				v.errorf(pointer(ptr, "type", i), "invalid schema type %q", t)
+			}
+		}
+	}
+
+	if s.Nullable && v.is31 {
+		v.errorf(pointer(ptr, "nullable"), `nullable is not supported in OpenAPI 3.1, add "null" to the type instead`)
+	}
+	if len(s.Examples) > 0 && !v.is31 {
+		v.errorf(pointer(ptr, "examples"), "schema examples require OpenAPI 3.1, use example in 3.0")
+	}
+	if s.Type == "array" && s.Items == nil && !v.is31 {
+		v.errorf(ptr, "items is required for array schemas")
+	}
+	if s.Pattern != "" {
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			v.errorf(pointer(ptr, "pattern"), "invalid pattern: %v", err)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+		v.validateSchema(pointer(ptr, "properties", name), s.Properties[name])
+	}
+	v.validateSchema(pointer(ptr, "items"), s.Items)
+}
+
+// validateRef checks that a local $ref such as #/components/schemas/User resolves.
+// References to other documents are not followed.
+func (v *validator) validateRef(ptr, ref string) {
+	if v.skipRefs || !strings.HasPrefix(ref, "#") {
+		return
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+	if len(tokens) == 3 && tokens[0] == "components" && v.openapi.Components != nil {
+		components := v.openapi.Components
+		name := unescapePointerToken(tokens[2])
+
+		var found bool
+		switch tokens[1] {
+		case "schemas":
+			_, found = components.Schemas[name]
+		case "parameters":
+			_, found = components.Parameters[name]
+		case "responses":
+			_, found = components.Responses[name]
+		case "requestBodies":
+			_, found = components.RequestBodies[name]
+		case "securitySchemes":
+			_, found = components.SecuritySchemes[name]
+		}
+		if found {
+			return
+		}
+	}
+
+	v.errorf(ptr, "$ref %q does not resolve", ref)
+}
+
+func (v *validator) validateComponents() {
+	components := v.openapi.Components
+	if components == nil {
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(components.Schemas)) {
+		v.validateSchema(pointer("/components/schemas", name), components.Schemas[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(components.Parameters)) {
+		ptr := pointer("/components/parameters", name)
+		if param := components.Parameters[name]; param == nil {
+			v.errorf(ptr, "parameter must be an object")
+		} else if param.Ref != "" {
+			v.validateRef(pointer(ptr, "$ref"), param.Ref)
+		} else {
+			v.validateParameter(ptr, param)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(components.Responses)) {
+		v.validateResponse(pointer("/components/responses", name), components.Responses[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(components.RequestBodies)) {
+		ptr := pointer("/components/requestBodies", name)
+		if body := components.RequestBodies[name]; body == nil {
+			v.errorf(ptr, "request body must be an object")
+		} else if body.Ref != "" {
+			v.validateRef(pointer(ptr, "$ref"), body.Ref)
+		} else {
+			v.validateContent(ptr, body.Content, true)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(components.SecuritySchemes)) {
+		ptr := pointer("/components/securitySchemes", name)
+		scheme := components.SecuritySchemes[name]
+		if scheme == nil {
+			v.errorf(ptr, "security scheme must be an object")
+			continue
+		}
+
+		switch {
+		case scheme.Type == "":
+			v.errorf(pointer(ptr, "type"), "security scheme type is required")
+		case !slices.Contains(securitySchemeTypes, scheme.Type) && !(v.is31 && scheme.Type == "mutualTLS"):
+			v.errorf(pointer(ptr, "type"), "invalid security scheme type %q", scheme.Type)
+		case scheme.Type == "http" && scheme.Scheme == "":
+			v.errorf(pointer(ptr, "scheme"), "http security schemes require a scheme")
+		case scheme.Type == "apiKey":
+			if scheme.Name == "" {
+				v.errorf(pointer(ptr, "name"), "apiKey security schemes require a name")
+			}
+			if !slices.Contains([]string{"query", "header", "cookie"}, scheme.In) {
+				v.errorf(pointer(ptr, "in"), "apiKey security schemes must be in query, header or cookie")
+			}
+		}
+	}
+}
+
+func unescapePointerToken(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// ValidateSpec decodes a JSON or YAML spec and validates it like Validate.
+// Local $refs are resolved against the document as written rather than the
+// OpenAPI struct, so references from keywords the struct does not model, such
+// as allOf, additionalProperties, callbacks or path item $refs, are checked too.
+func ValidateSpec(data []byte, format string) (*OpenAPI, []ValidationError, error) {
+	openapi, err := UnmarshalSpec(data, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, err := unmarshalDocument(data, format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := &validator{
+		openapi:      openapi,
+		is31:         strings.HasPrefix(openapi.OpenAPI, "3.1."),
+		skipRefs:     true,
+		operationIDs: make(map[string]string),
+	}
+	v.validate()
+	v.validateDocumentRefs("", document, document, false)
+	return openapi, v.errors, nil
+}
+
+var (
+	// literalKeywords hold values rather than objects of the spec, so a $ref in them is not a reference
+	literalKeywords = []string{"example", "default", "enum", "const", "value"}
+	// namedKeywords map names chosen by the author, which may equal keywords, to objects
+	namedKeywords = []string{
+		"paths", "webhooks", "callbacks", "schemas", "responses", "parameters", "examples", "requestBodies",
+		"headers", "securitySchemes", "links", "pathItems", "content", "encoding", "properties",
+		"patternProperties", "$defs", "dependentSchemas",
+	}
+)
+
+// validateDocumentRefs checks that the local $refs below node, at ptr in document,
+// resolve. named tells whether the keys of node are names rather than keywords.
+func (v *validator) validateDocumentRefs(ptr string, node, document any, named bool) {
+	for _, key := range documentKeys(node) {
+		value, _ := documentChild(node, key)
+		if named {
+			v.validateDocumentRefs(pointer(ptr, key), value, document, false)
+			continue
+		}
+
+		switch {
+		case key == "$ref":
+			if ref, ok := value.(string); ok && strings.HasPrefix(ref, "#") {
+				if _, found := resolveDocumentRef(document, ref); !found {
+					v.errorf(pointer(ptr, "$ref"), "$ref %q does not resolve", ref)
+				}
+			}
+		case slices.Contains(literalKeywords, key), strings.HasPrefix(key, "x-"):
+		case key == "examples":
+			// Schema examples are values, media type examples are named example objects
+			if _, isList := value.([]any); !isList {
+				v.validateDocumentRefs(pointer(ptr, key), value, document, true)
+			}
+		default:
+			v.validateDocumentRefs(pointer(ptr, key), value, document, slices.Contains(namedKeywords, key))
+		}
+	}
+}
+
+// documentKeys returns the keys of an object in document order (sorted), or
+// the indexes of an array
+func documentKeys(node any) []string {
+	var keys []string
+	switch n := node.(type) {
+	case map[string]any:
+		keys = slices.Sorted(maps.Keys(n))
+	case map[any]any:
+		for key := range n {
+			keys = append(keys, fmt.Sprint(key))
+		}
+		slices.Sort(keys)
+	case []any:
+		for i := range n {
+			keys = append(keys, strconv.Itoa(i))
+		}
+	}
+	return keys
+}
+
+// documentChild returns the member key of an object, or the element of an array at index key
+func documentChild(node any, key string) (any, bool) {
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[key]
+		return child, ok
+	case map[any]any:
+		// YAML keys such as response codes may not be strings
+		for k, child := range n {
+			if fmt.Sprint(k) == key {
+				return child, true
+			}
+		}
+	case []any:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(n) {
+			return n[i], true
+		}
+	}
+	return nil, false
+}
+
+// resolveDocumentRef follows a local $ref such as #/components/schemas/User
+// through document. Anchors such as #user are not followed and count as resolved.
+func resolveDocumentRef(document any, ref string) (any, bool) {
+	fragment, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		return nil, false
+	}
+	if fragment == "" {
+		return document, true
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, true
+	}
+
+	node := document
+	for _, token := range strings.Split(fragment[1:], "/") {
+		child, ok := documentChild(node, unescapePointerToken(token))
+		if !ok {
+			return nil, false
+		}
+		node = child
+	}
+	return node, true
+}
@@ -0,0 +1,222 @@
+package generator
+
+import (
+	"slices"
+	"testing"
+)
+
+// validSpec returns a spec without problems, which the cases of TestValidate break
+func validSpec() *OpenAPI {
+	return &OpenAPI{
+		OpenAPI: OpenAPIVersion30,
+		Info:    Info{Title: "Users", Version: "1.0.0"},
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Get: &Operation{
+					OperationID: "getUser",
+					Parameters:  []*ParameterObject{{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}}},
+					Responses: map[string]*ResponseWrapper{
+						"200": {
+							Description: "OK",
+							Content:     map[string]MediaType{"application/json": {Schema: &Schema{Ref: "#/components/schemas/User"}}},
+						},
+					},
+				},
+			},
+		},
+		Components: &Components{
+			Schemas: map[string]*Schema{
+				"User": {Type: "object", Properties: map[string]*Schema{"id": {Type: "string"}}},
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(spec *OpenAPI)
+		want   []string // pointers of the errors
+	}{
+		{
+			name:   "Valid",
+			modify: func(spec *OpenAPI) {},
+		},
+		{
+			name:   "MissingTitle",
+			modify: func(spec *OpenAPI) { spec.Info.Title = "" },
+			want:   []string{"/info/title"},
+		},
+		{
+			name:   "UnsupportedVersion",
+			modify: func(spec *OpenAPI) { spec.OpenAPI = "2.0" },
+			want:   []string{"/openapi"},
+		},
+		{
+			name: "InvalidResponseCode",
+			modify: func(spec *OpenAPI) {
+				spec.Paths["/users/{id}"].Get.Responses["20"] = &ResponseWrapper{Description: "OK"}
+			},
+			want: []string{"/paths/~1users~1{id}/get/responses/20"},
+		},
+		{
+			name: "DefaultResponse",
+			modify: func(spec *OpenAPI) {
+				spec.Paths["/users/{id}"].Get.Responses["default"] = &ResponseWrapper{Description: "Error"}
+			},
+		},
+		{
+			name:   "UndeclaredPathParameter",
+			modify: func(spec *OpenAPI) { spec.Paths["/users/{id}"].Get.Parameters = nil },
+			want:   []string{"/paths/~1users~1{id}/get"},
+		},
+		{
+			name: "DuplicateOperationID",
+			modify: func(spec *OpenAPI) {
+				spec.Paths["/users"] = &PathItem{Get: &Operation{OperationID: "getUser", Responses: map[string]*ResponseWrapper{"200": {Description: "OK"}}}}
+			},
+			want: []string{"/paths/~1users~1{id}/get/operationId"},
+		},
+		{
+			name: "DanglingRef",
+			modify: func(spec *OpenAPI) {
+				spec.Components.Schemas["User"].Properties["address"] = &Schema{Ref: "#/components/schemas/Address"}
+			},
+			want: []string{"/components/schemas/User/properties/address/$ref"},
+		},
+		{
+			name:   "WebhooksIn30",
+			modify: func(spec *OpenAPI) { spec.Webhooks = map[string]*PathItem{"userCreated": {}} },
+			want:   []string{"/webhooks"},
+		},
+		{
+			name: "NullableIn31",
+			modify: func(spec *OpenAPI) {
+				spec.OpenAPI = OpenAPIVersion31
+				spec.Components.Schemas["User"].Properties["id"].Nullable = true
+			},
+			want: []string{"/components/schemas/User/properties/id/nullable"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSpec()
+			tt.modify(spec)
+
+			var got []string
+			for _, e := range Validate(spec) {
+				got = append(got, e.Pointer)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Validate() = %q, want errors at %q", Validate(spec), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		spec   string
+		want   []string // pointers of the errors
+	}{
+		{
+			name:   "Valid",
+			format: FormatJSON,
+			spec: `{"openapi": "3.1.0", "info": {"title": "Users", "version": "1"},
+				"paths": {"/users": {"get": {"responses": {"200": {"$ref": "#/components/responses/Users"}}}}},
+				"components": {
+					"responses": {"Users": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}},
+					"schemas": {"User": {"type": "object"}}
+				}}`,
+		},
+		{
+			name:   "AllOf",
+			format: FormatJSON,
+			spec: `{"openapi": "3.1.0", "info": {"title": "Users", "version": "1"},
+				"components": {"schemas": {
+					"User": {"allOf": [{"$ref": "#/components/schemas/Base"}, {"$ref": "#/components/schemas/Missing"}]},
+					"Base": {"type": "object"}
+				}}}`,
+			want: []string{"/components/schemas/User/allOf/1/$ref"},
+		},
+		{
+			name:   "AdditionalProperties",
+			format: FormatJSON,
+			spec: `{"openapi": "3.1.0", "info": {"title": "Users", "version": "1"},
+				"components": {"schemas": {"Users": {"type": "object", "additionalProperties": {"$ref": "#/components/schemas/User"}}}}}`,
+			want: []string{"/components/schemas/Users/additionalProperties/$ref"},
+		},
+		{
+			name:   "PathItemRef",
+			format: FormatJSON,
+			spec: `{"openapi": "3.1.0", "info": {"title": "Users", "version": "1"},
+				"paths": {"/users": {"$ref": "#/components/pathItems/Users"}}}`,
+			want: []string{"/paths/~1users/$ref"},
+		},
+		{
+			name:   "Callback",
+			format: FormatJSON,
+			spec: `{"openapi": "3.0.3", "info": {"title": "Users", "version": "1"},
+				"paths": {"/subscribe": {"post": {"responses": {"201": {"description": "Created"}},
+					"callbacks": {"event": {"{$request.body#/url}": {"post": {"requestBody": {"$ref": "#/components/requestBodies/Event"},
+						"responses": {"200": {"description": "OK"}}}}}}}}}}`,
+			want: []string{"/paths/~1subscribe/post/callbacks/event/{$request.body#~1url}/post/requestBody/$ref"},
+		},
+		{
+			name:   "RefIntoPaths",
+			format: FormatJSON,
+			spec: `{"openapi": "3.1.0", "info": {"title": "Users", "version": "1"},
+				"paths": {
+					"/users/{id}": {"get": {"parameters": [{"name": "id", "in": "path", "required": true}, {"name": "q", "in": "query"}], "responses": {"200": {"description": "OK"}}}},
+					"/people": {"get": {"parameters": [{"$ref": "#/paths/~1users~1%7Bid%7D/get/parameters/1"}], "responses": {"200": {"description": "OK"}}}}
+				}}`,
+		},
+		{
+			name:   "Literals",
+			format: FormatJSON,
+			spec: `{"openapi": "3.1.0", "info": {"title": "Users", "version": "1"},
+				"components": {"schemas": {"Ref": {"type": "object",
+					"properties": {"default": {"$ref": "#/components/schemas/Missing"}},
+					"example": {"$ref": "#/not/a/reference"},
+					"x-internal": {"$ref": "#/not/a/reference"}
+				}}}}`,
+			want: []string{"/components/schemas/Ref/properties/default/$ref"},
+		},
+		{
+			name:   "YAML",
+			format: FormatYAML,
+			spec: `openapi: 3.0.3
+info: {title: Users, version: "1"}
+paths:
+  /users:
+    get:
+      responses:
+        200:
+          description: OK
+        404:
+          $ref: "#/paths/~1users/get/responses/200"
+        500:
+          $ref: "#/components/responses/Error"
+`,
+			want: []string{"/paths/~1users/get/responses/500/$ref"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs, err := ValidateSpec([]byte(tt.spec), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Pointer)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateSpec() = %q, want errors at %q", errs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+)
+
+const (
+	OpenAPIVersion30 = "3.0.0"
+	OpenAPIVersion31 = "3.1.0"
+)
+
+// Options configures GenerateSpecWithOptions
+type Options struct {
+	// OpenAPIVersion is "3.0" (the default) or "3.1"; "3.0.0" and "3.1.0" are accepted too
+	OpenAPIVersion string
+	// Strict turns warnings, such as missing models or path parameters not in
+	// the route path, into errors
+	Strict bool
+	// Servers are written to the servers of the spec
+	Servers []Server
+	// SecuritySchemes are added to the components, replacing schemes of the
+	// same name derived from @Security annotations
+	SecuritySchemes map[string]*SecuritySchemeObject
+	// DefaultResponses, keyed by status code, are added to every operation
+	// that does not document a response for the status code itself
+	DefaultResponses map[string]parser.Response
+	// NullablePointers marks the pointer fields of models nullable, which 3.1
+	// writes as a "null" type
+	NullablePointers bool
+}
+
+func resolveOpenAPIVersion(version string) (string, error) {
+	switch strings.TrimPrefix(version, "v") {
+	case "", "3.0", "3.0.0":
+		return OpenAPIVersion30, nil
+	case "3.1", "3.1.0":
+		return OpenAPIVersion31, nil
+	}
+	return "", fmt.Errorf("unsupported OpenAPI version %q (supported: 3.0, 3.1)", version)
+}
+
+// convertTo31 rewrites the 3.0 keywords used while generating into their
+// JSON Schema 2020-12 form: nullable becomes a "null" type and example an
+// examples array
+func convertTo31(openapi *OpenAPI) {
+	walkSchemas(openapi, func(s *Schema) {
+		if s.Nullable {
+			if s.Type != "" {
+				s.Types = []string{s.Type, "null"}
+				s.Type = ""
+			}
+			s.Nullable = false
+		}
+		if s.Example != nil {
+			s.Examples = append(s.Examples, s.Example)
+			s.Example = nil
+		}
+	})
+
+	// The license identifier and url are mutually exclusive in 3.1
+	if license := openapi.Info.License; license != nil && license.Identifier != "" {
+		license.URL = ""
+	}
+}
+
+// convertTo30 drops what OpenAPI 3.0 cannot express: webhooks, the license
+// identifier and keywords next to a $ref, which 3.0 ignores
+func convertTo30(openapi *OpenAPI, diags *parser.Diagnostics) {
+	for _, name := range slices.Sorted(maps.Keys(openapi.Webhooks)) {
+		diags.Warnf(parser.CodeRequiresOpenAPI31, "", pointer("/webhooks", name), "webhook %s requires OpenAPI 3.1, skipping it", name)
+	}
+	openapi.Webhooks = nil
+
+	if license := openapi.Info.License; license != nil && license.Identifier != "" {
+		diags.Warnf(parser.CodeRequiresOpenAPI31, "", "/info/license/identifier", "license identifier %s requires OpenAPI 3.1, skipping it", license.Identifier)
+		license.Identifier = ""
+	}
+
+	walkSchemas(openapi, func(s *Schema) {
+		if s.Ref != "" {
+			*s = Schema{Ref: s.Ref}
+		}
+	})
+}
+
+// walkSchemas calls visit for every schema of the spec, including nested property and item schemas
+func walkSchemas(openapi *OpenAPI, visit func(*Schema)) {
+	var walk func(s *Schema)
+	walk = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		visit(s)
+		for _, prop := range s.Properties {
+			walk(prop)
+		}
+		walk(s.Items)
+	}
+
+	walkContent := func(content map[string]MediaType) {
+		for _, media := range content {
+			walk(media.Schema)
+		}
+	}
+
+	walkParameters := func(parameters []*ParameterObject) {
+		for _, param := range parameters {
+			if param != nil {
+				walk(param.Schema)
+			}
+		}
+	}
+
+	walkResponse := func(resp *ResponseWrapper) {
+		if resp == nil {
+			return
+		}
+		walkContent(resp.Content)
+		for _, header := range resp.Headers {
+			if header != nil {
+				walk(header.Schema)
+			}
+		}
+	}
+
+	walkPathItems := func(items map[string]*PathItem) {
+		for _, item := range items {
+			if item == nil {
+				continue
+			}
+			walkParameters(item.Parameters)
+			for _, op := range item.Operations() {
+				walkParameters(op.Parameters)
+				if op.RequestBody != nil {
+					walkContent(op.RequestBody.Content)
+				}
+				for _, resp := range op.Responses {
+					walkResponse(resp)
+				}
+			}
+		}
+	}
+
+	walkPathItems(openapi.Paths)
+	walkPathItems(openapi.Webhooks)
+	if components := openapi.Components; components != nil {
+		for _, schema := range components.Schemas {
+			walk(schema)
+		}
+		walkParameters(slices.Collect(maps.Values(components.Parameters)))
+		for _, body := range components.RequestBodies {
+			if body != nil {
+				walkContent(body.Content)
+			}
+		}
+		for _, resp := range components.Responses {
+			walkResponse(resp)
+		}
+	}
+}
@@ -3,11 +3,12 @@ package parser
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
-	"strings"
+	"go/types"
 )
 
-func DetectRequestBodyType(fn *ast.FuncDecl) (map[string]string, error) {
+func DetectRequestBodyType(fn *ast.FuncDecl, info *types.Info) (map[string]string, error) {
 	result := make(map[string]string)
 
 	// Inspect the function body to find ShouldBindJSON calls
@@ -18,29 +19,16 @@ func DetectRequestBodyType(fn *ast.FuncDecl) (map[string]string, error) {
 			return true
 		}
 
-		// Check if the function being called is ShouldBindJSON
+		// Check if the function being called is (*gin.Context).ShouldBindJSON
 		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
-		if !ok || selExpr.Sel.Name != "ShouldBindJSON" {
+		if !ok || selExpr.Sel.Name != "ShouldBindJSON" || !isGinContextCall(selExpr, info) {
 			return true
 		}
 
-		// Extract the argument passed to ShouldBindJSON
+		// Resolve the type of the argument, e.g. &req or a pointer variable
 		if len(callExpr.Args) == 1 {
-			// Check if the argument is a unary expression
-			unaryExpr, ok := callExpr.Args[0].(*ast.UnaryExpr)
-			if ok {
-				// Check if the unary expression contains an identifier
-				ident, ok := unaryExpr.X.(*ast.Ident)
-				if ok {
-					// Extract the type of the variable from the declaration
-					if ident.Obj != nil && ident.Obj.Decl != nil {
-						valueSpec, ok := ident.Obj.Decl.(*ast.ValueSpec)
-						if ok && len(valueSpec.Type.(*ast.Ident).Name) > 0 {
-							typeName := valueSpec.Type.(*ast.Ident).Name
-							result[typeName] = "" // struct name
-						}
-					}
-				}
+			if typeName, ok := modelTypeName(info.TypeOf(callExpr.Args[0])); ok {
+				result[typeName] = "" // struct name
 			}
 		}
 
@@ -53,7 +41,7 @@ func DetectRequestBodyType(fn *ast.FuncDecl) (map[string]string, error) {
 	return nil, fmt.Errorf("no ShouldBindJSON found")
 }
 
-func DetectResponseModel(fn *ast.FuncDecl) map[string]string {
+func DetectResponseModel(fn *ast.FuncDecl, info *types.Info) map[string]string {
 	responses := make(map[string]string)
 
 	ast.Inspect(fn.Body, func(n ast.Node) bool {
@@ -62,23 +50,16 @@ func DetectResponseModel(fn *ast.FuncDecl) map[string]string {
 			return true
 		}
 
-		// Check for selector expression: c.JSON
-		if selExpr, ok := call.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "JSON" {
-			// Attempt to get status code and response model type
-			statusCodeLit, ok1 := call.Args[0].(*ast.BasicLit)
-			responseExpr := call.Args[1]
-
-			if ok1 {
-				status := strings.Trim(statusCodeLit.Value, "\"")
-
-				switch expr := responseExpr.(type) {
-				case *ast.CompositeLit:
-					if ident, ok := expr.Type.(*ast.Ident); ok {
-						responses[status] = ident.Name
-					}
-				case *ast.Ident:
-					responses[status] = expr.Name
-				}
+		// Check for selector expression: c.JSON on a *gin.Context
+		if selExpr, ok := call.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "JSON" && isGinContextCall(selExpr, info) {
+			// Status codes may be literals or constants such as http.StatusOK
+			status, ok := constantValue(call.Args[0], info)
+			if !ok {
+				return true
+			}
+
+			if typeName, ok := modelTypeName(info.TypeOf(call.Args[1])); ok {
+				responses[status] = typeName
 			}
 		}
 
@@ -88,7 +69,7 @@ func DetectResponseModel(fn *ast.FuncDecl) map[string]string {
 	return responses
 }
 
-func DetectParametersAndQuery(fn *ast.FuncDecl) ([]Parameter, error) {
+func DetectParametersAndQuery(fn *ast.FuncDecl, info *types.Info) ([]Parameter, error) {
 	var parameters []Parameter
 
 	// Ensure the function has a body
@@ -106,15 +87,13 @@ func DetectParametersAndQuery(fn *ast.FuncDecl) ([]Parameter, error) {
 
 		// Check if the function being called is a Gin context method
 		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
-		if !ok {
+		if !ok || !isGinContextCall(selExpr, info) {
 			return true
 		}
 
 		// Detect path parameters (e.g., c.Param("id"))
 		if selExpr.Sel.Name == "Param" && len(callExpr.Args) == 1 {
-			arg, ok := callExpr.Args[0].(*ast.BasicLit)
-			if ok && arg.Kind == token.STRING {
-				paramName := strings.Trim(arg.Value, "\"")
+			if paramName, ok := constantValue(callExpr.Args[0], info); ok {
 				parameters = append(parameters, Parameter{
 					Name:        paramName,
 					In:          "path",
@@ -127,9 +106,7 @@ func DetectParametersAndQuery(fn *ast.FuncDecl) ([]Parameter, error) {
 
 		// Detect query parameters (e.g., c.Query("name"))
 		if selExpr.Sel.Name == "Query" && len(callExpr.Args) == 1 {
-			arg, ok := callExpr.Args[0].(*ast.BasicLit)
-			if ok && arg.Kind == token.STRING {
-				queryName := strings.Trim(arg.Value, "\"")
+			if queryName, ok := constantValue(callExpr.Args[0], info); ok {
 				parameters = append(parameters, Parameter{
 					Name:        queryName,
 					In:          "query",
@@ -142,9 +119,7 @@ func DetectParametersAndQuery(fn *ast.FuncDecl) ([]Parameter, error) {
 
 		// Detect headers (e.g., c.GetHeader("X-Correlation-ID"))
 		if selExpr.Sel.Name == "GetHeader" && len(callExpr.Args) == 1 {
-			arg, ok := callExpr.Args[0].(*ast.BasicLit)
-			if ok && arg.Kind == token.STRING {
-				headerName := strings.Trim(arg.Value, "\"")
+			if headerName, ok := constantValue(callExpr.Args[0], info); ok {
 				parameters = append(parameters, Parameter{
 					Name:        headerName,
 					In:          "header",
@@ -164,7 +139,7 @@ func DetectParametersAndQuery(fn *ast.FuncDecl) ([]Parameter, error) {
 	return nil, fmt.Errorf("no parameters or query strings found")
 }
 
-func DetectHeaders(fn *ast.FuncDecl) ([]Header, error) {
+func DetectHeaders(fn *ast.FuncDecl, info *types.Info) ([]Header, error) {
 	var headers []Header
 
 	// Ensure the function has a body
@@ -180,17 +155,15 @@ func DetectHeaders(fn *ast.FuncDecl) ([]Header, error) {
 			return true
 		}
 
-		// Check if the function being called is c.Header
+		// Check if the function being called is c.Header on a *gin.Context
 		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
-		if !ok || selExpr.Sel.Name != "Header" {
+		if !ok || selExpr.Sel.Name != "Header" || !isGinContextCall(selExpr, info) {
 			return true
 		}
 
 		// Extract the header name
 		if len(callExpr.Args) == 2 {
-			arg, ok := callExpr.Args[0].(*ast.BasicLit)
-			if ok && arg.Kind == token.STRING {
-				headerName := strings.Trim(arg.Value, "\"")
+			if headerName, ok := constantValue(callExpr.Args[0], info); ok {
 				headers = append(headers, Header{
 					StatusCode:  "200",
 					Name:        headerName,
@@ -209,3 +182,98 @@ func DetectHeaders(fn *ast.FuncDecl) ([]Header, error) {
 	}
 	return nil, fmt.Errorf("no headers found")
 }
+
+// DetectSkippedCalls returns the calls in fn that are named like a gin.Context
+// accessor (Param, Query, GetHeader, Header, JSON, ShouldBindJSON) but are made
+// on another receiver, such as db.Query(...), and were therefore not used for detection
+func DetectSkippedCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet) []SkippedCall {
+	return detectSkippedMethodCalls(fn, info, fset, ginContextMethods, isGinContextCall, nil)
+}
+
+// ginContextMethods are the gin.Context methods used for auto-detection
+var ginContextMethods = map[string]bool{
+	"Param":          true,
+	"Query":          true,
+	"GetHeader":      true,
+	"Header":         true,
+	"JSON":           true,
+	"ShouldBindJSON": true,
+}
+
+// isGinContextCall reports whether sel selects a method of *gin.Context, either
+// directly or promoted through a wrapper type that embeds the context
+func isGinContextCall(sel *ast.SelectorExpr, info *types.Info) bool {
+	return isMethodOf(sel, info, ginPkgPath, "Context")
+}
+
+// isNamedType reports whether t, or the type it points to, is pkgPath.name
+func isNamedType(t types.Type, pkgPath, name string) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == name
+}
+
+// funcPackage returns the package a function is declared in
+func funcPackage(fn *ast.FuncDecl, info *types.Info) *types.Package {
+	if obj := info.Defs[fn.Name]; obj != nil {
+		return obj.Pkg()
+	}
+	return nil
+}
+
+// constantValue returns the value of a constant string or integer expression,
+// such as "id", http.StatusOK or a named string constant
+func constantValue(expr ast.Expr, info *types.Info) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil {
+		return "", false
+	}
+
+	switch tv.Value.Kind() {
+	case constant.String:
+		return constant.StringVal(tv.Value), true
+	case constant.Int:
+		return tv.Value.ExactString(), true
+	}
+	return "", false
+}
+
+// modelTypeName returns the name of a struct model type qualified by its import
+// path, "example.com/app/dto.CreateUserRequest", which names it in the registry
+// even when other packages declare a type of the same name
+func modelTypeName(t types.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok {
+		return "", false
+	}
+
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return "", false
+	}
+
+	return types.TypeString(named, (*types.Package).Path), true
+}
+
+// packageQualifier qualifies types from other packages by package name rather than import path
+func packageQualifier(pkg *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return other.Name()
+	}
+}
@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// chiAdapter detects net/http accessors and chi.URLParam in
+// func(w http.ResponseWriter, r *http.Request) handlers
+type chiAdapter struct{}
+
+func (chiAdapter) Name() string {
+	return "chi"
+}
+
+func (chiAdapter) IsHandler(fn *ast.FuncDecl, info *types.Info) bool {
+	return hasHandlerParam(fn, info, isHTTPRequest)
+}
+
+func (chiAdapter) DetectParameters(fn *ast.FuncDecl, info *types.Info) []Parameter {
+	var parameters []Parameter
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		switch {
+		case isPackageFunc(sel, info, "URLParam", isChiPackage):
+			// Detect path parameters (e.g., chi.URLParam(r, "id"))
+			if len(call.Args) == 2 {
+				if name, ok := constantValue(call.Args[1], info); ok {
+					parameters = append(parameters, pathParameter(wildcardParamName(name)))
+				}
+			}
+		case isQueryValuesGet(sel, info):
+			// Detect query parameters (e.g., r.URL.Query().Get("name"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, queryParameter(name))
+			}
+		case isRequestHeaderGet(sel, info):
+			// Detect headers (e.g., r.Header.Get("X-Correlation-ID"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, headerParameter(name))
+			}
+		}
+	})
+
+	return parameters
+}
+
+func (chiAdapter) DetectRequestBody(fn *ast.FuncDecl, info *types.Info) []string {
+	return detectJSONDecode(fn, info)
+}
+
+func (chiAdapter) DetectResponses(fn *ast.FuncDecl, info *types.Info) map[string]string {
+	return detectJSONEncode(fn, info)
+}
+
+func (chiAdapter) DetectHeaders(fn *ast.FuncDecl, info *types.Info) []Header {
+	return detectResponseHeaderSet(fn, info)
+}
+
+// DetectSkippedCalls returns nothing: chi handlers use net/http types, whose
+// accessors are recognised by their receiver type rather than by name
+func (chiAdapter) DetectSkippedCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet) []SkippedCall {
+	return nil
+}
+
+func (chiAdapter) DetectRegistrations(pkgs []Package) []Registration {
+	return nil
+}
+
+// NormalizePath strips chi's regular expressions from {name:regexp} segments,
+// returning them as pattern constraints, and names the trailing * wildcard
+func (chiAdapter) NormalizePath(path string) (string, map[string]string) {
+	var b strings.Builder
+	patterns := make(map[string]string)
+
+	for i := 0; i < len(path); i++ {
+		if path[i] != '{' {
+			b.WriteByte(path[i])
+			continue
+		}
+
+		// Find the matching brace; the regexp itself may contain {n} quantifiers
+		end, depth := -1, 0
+		for j := i; j < len(path) && end < 0; j++ {
+			switch path[j] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end < 0 {
+			b.WriteString(path[i:])
+			break
+		}
+
+		name, pattern, hasPattern := strings.Cut(path[i+1:end], ":")
+		b.WriteString("{" + name + "}")
+		if hasPattern {
+			patterns[name] = anchorPattern(pattern)
+		}
+		i = end
+	}
+
+	normalized := b.String()
+	if strings.HasSuffix(normalized, "/*") {
+		normalized = strings.TrimSuffix(normalized, "*") + "{" + wildcardParamName("*") + "}"
+	}
+
+	if len(patterns) == 0 {
+		return normalized, nil
+	}
+	return normalized, patterns
+}
+
+// anchorPattern anchors a chi route regexp the same way chi does when matching a segment
+func anchorPattern(pattern string) string {
+	if !strings.HasPrefix(pattern, "^") {
+		pattern = "^" + pattern
+	}
+	if !strings.HasSuffix(pattern, "$") {
+		pattern += "$"
+	}
+	return pattern
+}
+
+func isChiPackage(path string) bool {
+	return path == "github.com/go-chi/chi" || strings.HasPrefix(path, "github.com/go-chi/chi/")
+}
+
+// isHTTPRequest reports whether t is *http.Request, the parameter of every net/http handler
+func isHTTPRequest(t types.Type) bool {
+	return isNamedType(t, "net/http", "Request")
+}
+
+// isQueryValuesGet reports whether sel is Get on url.Values, e.g. r.URL.Query().Get
+func isQueryValuesGet(sel *ast.SelectorExpr, info *types.Info) bool {
+	return sel.Sel.Name == "Get" && isNamedType(info.TypeOf(sel.X), "net/url", "Values")
+}
+
+// detectJSONDecode returns the models decoded by json.NewDecoder(r.Body).Decode(&req)
+func detectJSONDecode(fn *ast.FuncDecl, info *types.Info) []string {
+	var models []string
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		if sel.Sel.Name != "Decode" || len(call.Args) != 1 || !isMethodOf(sel, info, "encoding/json", "Decoder") {
+			return
+		}
+		if typeName, ok := modelTypeName(info.TypeOf(call.Args[0])); ok {
+			models = append(models, typeName)
+		}
+	})
+
+	return models
+}
+
+// detectJSONEncode returns the models written by json.NewEncoder(w).Encode(v),
+// keyed by the status of the w.WriteHeader(code) preceding it in the same
+// block or an enclosing one (200 if none)
+func detectJSONEncode(fn *ast.FuncDecl, info *types.Info) map[string]string {
+	responses := make(map[string]string)
+
+	inspectStatusCalls(fn, "200", func(call *ast.CallExpr, sel *ast.SelectorExpr, status string) string {
+		switch {
+		case sel.Sel.Name == "WriteHeader" && isMethodOf(sel, info, "net/http", "ResponseWriter"):
+			if code, ok := firstStringArg(call, info); ok {
+				return code
+			}
+		case sel.Sel.Name == "Encode" && len(call.Args) == 1 && isMethodOf(sel, info, "encoding/json", "Encoder"):
+			if typeName, ok := modelTypeName(info.TypeOf(call.Args[0])); ok {
+				responses[status] = typeName
+			}
+		}
+		return status
+	})
+
+	return responses
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestDetectJSONEncode(t *testing.T) {
+	const src = `package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type User struct{ ID string }
+type ErrorResponse struct{ Message string }
+
+func Plain(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(User{})
+}
+
+func Created(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(User{})
+}
+
+func EarlyReturn(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: err.Error()})
+		return
+	}
+	json.NewEncoder(w).Encode(user)
+}
+
+func Switch(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(ErrorResponse{})
+	default:
+		json.NewEncoder(w).Encode(User{})
+	}
+}
+
+func Nested(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+	if r.URL.Query().Get("v") != "" {
+		json.NewEncoder(w).Encode(User{})
+	}
+}
+`
+	_, file, info := typeCheck(t, src, nil)
+
+	tests := []struct {
+		handler string
+		want    map[string]string
+	}{
+		{"Plain", map[string]string{"200": "example.com/handlers.User"}},
+		{"Created", map[string]string{"201": "example.com/handlers.User"}},
+		{"EarlyReturn", map[string]string{"400": "example.com/handlers.ErrorResponse", "200": "example.com/handlers.User"}},
+		{"Switch", map[string]string{"409": "example.com/handlers.ErrorResponse", "200": "example.com/handlers.User"}},
+		{"Nested", map[string]string{"202": "example.com/handlers.User"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.handler, func(t *testing.T) {
+			got := detectJSONEncode(funcDecl(t, file, tt.handler), info)
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("detectJSONEncode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
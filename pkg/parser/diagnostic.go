@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity tells whether a Diagnostic is an error or a warning
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic codes reported by the parser and the generator
+const (
+	CodePackageError        = "package-error"         // a package failed to load or type-check
+	CodeRouterMismatch      = "router-mismatch"       // @Router matches none of the handler's registrations
+	CodeSkippedCall         = "skipped-call"          // accessor-like call on a receiver of another type
+	CodeUnknownMethod       = "unknown-method"        // route method that is not an HTTP method
+	CodePathParamMismatch   = "path-param-mismatch"   // path parameter missing from the route path
+	CodeMissingModel        = "missing-model"         // model not found in the registry
+	CodeAmbiguousModel      = "ambiguous-model"       // model name declared by several packages
+	CodeSchemaNameCollision = "schema-name-collision" // types of different packages share a component name
+	CodeRequiresOpenAPI31   = "requires-openapi-3.1"
+	CodeMetadataFile        = "metadata-file" // global metadata file cannot be read
+)
+
+// Diagnostic is a problem found while parsing routes or generating a spec
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	Position string   `json:"position,omitempty"` // file:line:column in the source, when known
+	Pointer  string   `json:"pointer,omitempty"`  // JSON pointer into the generated spec, when known
+}
+
+func (d Diagnostic) String() string {
+	var sb strings.Builder
+	if d.Position != "" {
+		sb.WriteString(d.Position + ": ")
+	}
+	fmt.Fprintf(&sb, "%s: %s [%s]", d.Severity, d.Message, d.Code)
+	if d.Pointer != "" {
+		sb.WriteString(" at #" + d.Pointer)
+	}
+	return sb.String()
+}
+
+// Diagnostics is the list of diagnostics of a parse or generate run
+type Diagnostics []Diagnostic
+
+// Warnf appends a warning
+func (ds *Diagnostics) Warnf(code, position, pointer, format string, args ...any) {
+	*ds = append(*ds, Diagnostic{
+		Severity: SeverityWarning,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Position: position,
+		Pointer:  pointer,
+	})
+}
+
+// Strict raises every warning to an error, as the Strict options do
+func (ds Diagnostics) Strict() {
+	for i := range ds {
+		ds[i].Severity = SeverityError
+	}
+}
+
+// Err returns an error summarising the errors among ds, or nil if there are none
+func (ds Diagnostics) Err() error {
+	var errs []Diagnostic
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%s", errs[0])
+	}
+	return fmt.Errorf("%s (and %d more error(s))", errs[0], len(errs)-1)
+}
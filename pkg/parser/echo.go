@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+const echoPkgPath = "github.com/labstack/echo/v4"
+
+// echoContextMethods are the echo.Context methods used for auto-detection
+var echoContextMethods = map[string]bool{
+	"Param":      true,
+	"QueryParam": true,
+	"Bind":       true,
+	"JSON":       true,
+}
+
+// echoAdapter detects echo.Context accessors in func(c echo.Context) error handlers
+type echoAdapter struct{}
+
+func (echoAdapter) Name() string {
+	return "echo"
+}
+
+func (echoAdapter) IsHandler(fn *ast.FuncDecl, info *types.Info) bool {
+	return hasHandlerParam(fn, info, func(t types.Type) bool {
+		return isNamedType(t, echoPkgPath, "Context")
+	})
+}
+
+func (echoAdapter) DetectParameters(fn *ast.FuncDecl, info *types.Info) []Parameter {
+	var parameters []Parameter
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		switch {
+		case sel.Sel.Name == "Param" && isEchoContextCall(sel, info):
+			// Detect path parameters (e.g., c.Param("id"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, pathParameter(wildcardParamName(name)))
+			}
+		case sel.Sel.Name == "QueryParam" && isEchoContextCall(sel, info):
+			// Detect query parameters (e.g., c.QueryParam("name"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, queryParameter(name))
+			}
+		case isRequestHeaderGet(sel, info):
+			// Detect headers (e.g., c.Request().Header.Get("X-Correlation-ID"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, headerParameter(name))
+			}
+		}
+	})
+
+	return parameters
+}
+
+func (echoAdapter) DetectRequestBody(fn *ast.FuncDecl, info *types.Info) []string {
+	var models []string
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		// Detect c.Bind(&req)
+		if sel.Sel.Name != "Bind" || len(call.Args) != 1 || !isEchoContextCall(sel, info) {
+			return
+		}
+		if typeName, ok := modelTypeName(info.TypeOf(call.Args[0])); ok {
+			models = append(models, typeName)
+		}
+	})
+
+	return models
+}
+
+func (echoAdapter) DetectResponses(fn *ast.FuncDecl, info *types.Info) map[string]string {
+	responses := make(map[string]string)
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		// Detect c.JSON(http.StatusOK, UserResponse{...})
+		if sel.Sel.Name != "JSON" || len(call.Args) < 2 || !isEchoContextCall(sel, info) {
+			return
+		}
+
+		status, ok := constantValue(call.Args[0], info)
+		if !ok {
+			return
+		}
+		if typeName, ok := modelTypeName(info.TypeOf(call.Args[1])); ok {
+			responses[status] = typeName
+		}
+	})
+
+	return responses
+}
+
+// detectHeaders returns the headers set through c.Response().Header().Set
+func (echoAdapter) DetectHeaders(fn *ast.FuncDecl, info *types.Info) []Header {
+	return detectResponseHeaderSet(fn, info)
+}
+
+func (echoAdapter) DetectSkippedCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet) []SkippedCall {
+	return detectSkippedMethodCalls(fn, info, fset, echoContextMethods, isEchoContextCall, nil)
+}
+
+func (echoAdapter) DetectRegistrations(pkgs []Package) []Registration {
+	return nil
+}
+
+// NormalizePath converts echo's :param segments and trailing * wildcard into path templates
+func (echoAdapter) NormalizePath(path string) (string, map[string]string) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		case segment == "*":
+			segments[i] = "{" + wildcardParamName("*") + "}"
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+func isEchoContextCall(sel *ast.SelectorExpr, info *types.Info) bool {
+	return isMethodOf(sel, info, echoPkgPath, "Context")
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// echoStub declares the parts of echo v4 used by the tests
+const echoStub = `package echo
+
+import "net/http"
+
+type Context interface {
+	Param(name string) string
+	QueryParam(name string) string
+	Bind(i any) error
+	JSON(code int, i any) error
+	Request() *http.Request
+	Response() *Response
+}
+
+type Response struct{}
+
+func (r *Response) Header() http.Header { return nil }
+`
+
+const echoHandlers = `package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type User struct{ ID string }
+type ErrorResponse struct{ Message string }
+
+type Store struct{}
+
+func (Store) Bind(v any) error { return nil }
+
+func GetUser(c echo.Context) error {
+	id := c.Param("id")
+	_ = c.QueryParam("fields")
+	_ = c.Request().Header.Get("X-Request-ID")
+	c.Response().Header().Set("X-RateLimit-Remaining", "10")
+	return c.JSON(http.StatusOK, User{ID: id})
+}
+
+func CreateUser(c echo.Context) error {
+	var user User
+	if err := c.Bind(&user); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, &user)
+}
+
+func ServeFile(c echo.Context) error {
+	_ = c.Param("*")
+	return nil
+}
+
+func NotAHandler(s Store) error {
+	var user User
+	return s.Bind(&user)
+}
+`
+
+func TestEchoNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"/users/:id":               "/users/{id}",
+		"/users/:id/orders/:order": "/users/{id}/orders/{order}",
+		"/static/*":                "/static/{wildcard}",
+		"/users":                   "/users",
+	}
+	for path, want := range tests {
+		if got, _ := (echoAdapter{}).NormalizePath(path); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestEchoDetect(t *testing.T) {
+	_, file, info := typeCheck(t, echoHandlers, map[string]string{echoPkgPath: echoStub})
+	echo := echoAdapter{}
+
+	for name, want := range map[string]bool{"GetUser": true, "CreateUser": true, "NotAHandler": false} {
+		if got := echo.IsHandler(funcDecl(t, file, name), info); got != want {
+			t.Errorf("IsHandler(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	getUser := funcDecl(t, file, "GetUser")
+	var params []string
+	for _, p := range echo.DetectParameters(getUser, info) {
+		params = append(params, p.In+" "+p.Name)
+	}
+	if want := []string{"path id", "query fields", "header X-Request-ID"}; !slices.Equal(params, want) {
+		t.Errorf("DetectParameters(GetUser) = %v, want %v", params, want)
+	}
+	var wildcard []string
+	for _, p := range echo.DetectParameters(funcDecl(t, file, "ServeFile"), info) {
+		wildcard = append(wildcard, p.In+" "+p.Name)
+	}
+	if want := []string{"path wildcard"}; !slices.Equal(wildcard, want) {
+		t.Errorf("DetectParameters(ServeFile) = %v, want %v", wildcard, want)
+	}
+
+	headers := echo.DetectHeaders(getUser, info)
+	if len(headers) != 1 || headers[0].Name != "X-RateLimit-Remaining" {
+		t.Errorf("DetectHeaders(GetUser) = %+v, want X-RateLimit-Remaining", headers)
+	}
+
+	createUser := funcDecl(t, file, "CreateUser")
+	if got, want := echo.DetectRequestBody(createUser, info), []string{"example.com/handlers.User"}; !slices.Equal(got, want) {
+		t.Errorf("DetectRequestBody(CreateUser) = %v, want %v", got, want)
+	}
+	if got := echo.DetectRequestBody(funcDecl(t, file, "NotAHandler"), info); len(got) > 0 {
+		t.Errorf("DetectRequestBody(NotAHandler) = %v, want none", got)
+	}
+
+	responses := map[string]map[string]string{
+		"GetUser":    {"200": "example.com/handlers.User"},
+		"CreateUser": {"400": "example.com/handlers.ErrorResponse", "201": "example.com/handlers.User"},
+	}
+	for name, want := range responses {
+		if got := echo.DetectResponses(funcDecl(t, file, name), info); !maps.Equal(got, want) {
+			t.Errorf("DetectResponses(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
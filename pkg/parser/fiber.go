@@ -0,0 +1,232 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// fiberContextMethods are the fiber.Ctx methods used for auto-detection
+var fiberContextMethods = map[string]bool{
+	"Params":     true,
+	"Query":      true,
+	"Get":        true,
+	"Set":        true,
+	"BodyParser": true,
+	"JSON":       true,
+}
+
+// fiberAdapter detects fiber.Ctx accessors in func(c *fiber.Ctx) error handlers
+type fiberAdapter struct{}
+
+func (fiberAdapter) Name() string {
+	return "fiber"
+}
+
+func (fiberAdapter) IsHandler(fn *ast.FuncDecl, info *types.Info) bool {
+	return hasHandlerParam(fn, info, func(t types.Type) bool {
+		return isNamedType(t, "github.com/gofiber/fiber/v2", "Ctx") || isNamedType(t, "github.com/gofiber/fiber/v3", "Ctx")
+	})
+}
+
+func (fiberAdapter) DetectParameters(fn *ast.FuncDecl, info *types.Info) []Parameter {
+	var parameters []Parameter
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		if !isFiberContextCall(sel, info) {
+			return
+		}
+
+		name, ok := firstStringArg(call, info)
+		if !ok {
+			return
+		}
+
+		switch sel.Sel.Name {
+		case "Params":
+			// Detect path parameters (e.g., c.Params("id"))
+			parameters = append(parameters, pathParameter(fiberParamName(name)))
+		case "Query":
+			// Detect query parameters (e.g., c.Query("name"))
+			parameters = append(parameters, queryParameter(name))
+		case "Get":
+			// Detect request headers (e.g., c.Get("X-Correlation-ID"))
+			parameters = append(parameters, headerParameter(name))
+		}
+	})
+
+	return parameters
+}
+
+func (fiberAdapter) DetectRequestBody(fn *ast.FuncDecl, info *types.Info) []string {
+	var models []string
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		// Detect c.BodyParser(&req)
+		if sel.Sel.Name != "BodyParser" || len(call.Args) != 1 || !isFiberContextCall(sel, info) {
+			return
+		}
+		if typeName, ok := modelTypeName(info.TypeOf(call.Args[0])); ok {
+			models = append(models, typeName)
+		}
+	})
+
+	return models
+}
+
+// DetectResponses finds c.JSON(v) calls, taking the status from a c.Status(code).JSON(v)
+// chain or from the c.Status(code) call preceding it in the same block or an
+// enclosing one (200 if none)
+func (fiberAdapter) DetectResponses(fn *ast.FuncDecl, info *types.Info) map[string]string {
+	responses := make(map[string]string)
+
+	inspectStatusCalls(fn, "200", func(call *ast.CallExpr, sel *ast.SelectorExpr, status string) string {
+		if !isFiberContextCall(sel, info) {
+			return status
+		}
+
+		switch sel.Sel.Name {
+		case "Status":
+			if code, ok := firstStringArg(call, info); ok {
+				return code
+			}
+		case "JSON":
+			if len(call.Args) == 0 {
+				return status
+			}
+
+			responseStatus := status
+			if code, ok := fiberChainedStatus(sel.X, info); ok {
+				responseStatus = code
+			}
+			if typeName, ok := modelTypeName(info.TypeOf(call.Args[0])); ok {
+				responses[responseStatus] = typeName
+			}
+		}
+		return status
+	})
+
+	return responses
+}
+
+func (fiberAdapter) DetectHeaders(fn *ast.FuncDecl, info *types.Info) []Header {
+	var headers []Header
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		// Detect c.Set("X-RateLimit-Remaining", "29")
+		if sel.Sel.Name != "Set" || len(call.Args) != 2 || !isFiberContextCall(sel, info) {
+			return
+		}
+		if name, ok := firstStringArg(call, info); ok {
+			headers = append(headers, responseHeader(name))
+		}
+	})
+
+	return headers
+}
+
+func (fiberAdapter) DetectSkippedCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet) []SkippedCall {
+	return detectSkippedMethodCalls(fn, info, fset, fiberContextMethods, isFiberContextCall, isFiberLikeType)
+}
+
+func (fiberAdapter) DetectRegistrations(pkgs []Package) []Registration {
+	return nil
+}
+
+// NormalizePath converts fiber's :param and optional :param? segments, stripping
+// <constraint> suffixes (regex(...) constraints become patterns), and names the
+// * and + wildcards
+func (fiberAdapter) NormalizePath(path string) (string, map[string]string) {
+	patterns := make(map[string]string)
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			name, constraint, _ := strings.Cut(strings.TrimPrefix(segment, ":"), "<")
+			name = strings.TrimSuffix(name, "?")
+
+			constraint = strings.TrimSuffix(strings.TrimSuffix(constraint, "?"), ">")
+			if strings.HasPrefix(constraint, "regex(") && strings.HasSuffix(constraint, ")") {
+				patterns[name] = anchorPattern(strings.TrimSuffix(strings.TrimPrefix(constraint, "regex("), ")"))
+			}
+
+			segments[i] = "{" + name + "}"
+		case segment == "*" || segment == "+":
+			segments[i] = "{" + fiberParamName(segment) + "}"
+		}
+	}
+
+	if len(patterns) == 0 {
+		return strings.Join(segments, "/"), nil
+	}
+	return strings.Join(segments, "/"), patterns
+}
+
+// fiberParamName maps fiber's "*" and "+" wildcards to a named path parameter
+func fiberParamName(name string) string {
+	if name == "+" {
+		return wildcardParamName("*")
+	}
+	return wildcardParamName(name)
+}
+
+// fiberChainedStatus returns the status of a c.Status(code) call that expr consists of
+func fiberChainedStatus(expr ast.Expr, info *types.Info) (string, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Status" || !isFiberContextCall(sel, info) {
+		return "", false
+	}
+	return firstStringArg(call, info)
+}
+
+// isFiberLikeType reports whether t may be meant as a fiber context: a type of
+// a gofiber package, a type named like a context, or a struct embedding either.
+// Calls such as cache.Get or m.Set on other receivers are not worth reporting.
+func isFiberLikeType(t types.Type) bool {
+	named, ok := derefNamed(t)
+	if !ok {
+		return false
+	}
+	if isFiberLikeName(named) {
+		return true
+	}
+
+	if st, ok := named.Underlying().(*types.Struct); ok {
+		for i := 0; i < st.NumFields(); i++ {
+			if embedded, ok := derefNamed(st.Field(i).Type()); ok && st.Field(i).Embedded() && isFiberLikeName(embedded) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isFiberLikeName(named *types.Named) bool {
+	obj := named.Obj()
+	if obj.Pkg() != nil && strings.HasPrefix(obj.Pkg().Path(), "github.com/gofiber/") {
+		return true
+	}
+	return strings.Contains(obj.Name(), "Ctx") || strings.Contains(obj.Name(), "Context")
+}
+
+// derefNamed returns the named type of t or of the type t points to
+func derefNamed(t types.Type) (*types.Named, bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return named, ok
+}
+
+// isFiberContextCall matches methods of fiber v2's *fiber.Ctx and fiber v3's fiber.Ctx
+func isFiberContextCall(sel *ast.SelectorExpr, info *types.Info) bool {
+	return isMethodOf(sel, info, "github.com/gofiber/fiber/v2", "Ctx") ||
+		isMethodOf(sel, info, "github.com/gofiber/fiber/v3", "Ctx")
+}
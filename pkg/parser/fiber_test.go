@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"go/types"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// fiberStub declares the parts of fiber v2 used by the tests
+const fiberStub = `package fiber
+
+type Ctx struct{}
+
+func (c *Ctx) Status(status int) *Ctx                  { return c }
+func (c *Ctx) JSON(data any, ctype ...string) error    { return nil }
+func (c *Ctx) Params(key string, d ...string) string   { return "" }
+func (c *Ctx) Query(key string, d ...string) string    { return "" }
+func (c *Ctx) Get(key string, d ...string) string      { return "" }
+func (c *Ctx) Set(key, val string)                     {}
+func (c *Ctx) BodyParser(out any) error                { return nil }
+`
+
+const fiberHandlers = `package handlers
+
+import "github.com/gofiber/fiber/v2"
+
+type User struct{ ID string }
+type ErrorResponse struct{ Message string }
+
+type Cache struct{}
+
+func (Cache) Get(key string) string { return "" }
+func (Cache) Set(key, value string) {}
+
+type RequestCtx struct{}
+
+func (RequestCtx) Get(key string) string { return "" }
+
+type Wrapper struct{ *fiber.Ctx }
+
+func Chained(c *fiber.Ctx) error {
+	var user User
+	if err := c.BodyParser(&user); err != nil {
+		return c.Status(400).JSON(ErrorResponse{})
+	}
+	return c.Status(201).JSON(user)
+}
+
+func Unchained(c *fiber.Ctx) error {
+	var user User
+	if err := c.BodyParser(&user); err != nil {
+		c.Status(500)
+		return c.JSON(ErrorResponse{})
+	}
+	return c.JSON(user)
+}
+
+func Skipped(c *fiber.Ctx) error {
+	var cache Cache
+	var rc RequestCtx
+	cache.Set("k", cache.Get("k"))
+	_ = rc.Get("X-Request-ID")
+	return c.JSON(User{})
+}
+`
+
+func TestFiberDetectResponses(t *testing.T) {
+	_, file, info := typeCheck(t, fiberHandlers, map[string]string{"github.com/gofiber/fiber/v2": fiberStub})
+
+	tests := []struct {
+		handler string
+		want    map[string]string
+	}{
+		{"Chained", map[string]string{"400": "example.com/handlers.ErrorResponse", "201": "example.com/handlers.User"}},
+		{"Unchained", map[string]string{"500": "example.com/handlers.ErrorResponse", "200": "example.com/handlers.User"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.handler, func(t *testing.T) {
+			got := fiberAdapter{}.DetectResponses(funcDecl(t, file, tt.handler), info)
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("DetectResponses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiberDetectSkippedCalls(t *testing.T) {
+	fset, file, info := typeCheck(t, fiberHandlers, map[string]string{"github.com/gofiber/fiber/v2": fiberStub})
+
+	var receivers []string
+	for _, call := range (fiberAdapter{}).DetectSkippedCalls(funcDecl(t, file, "Skipped"), info, fset) {
+		receivers = append(receivers, call.Method+" on "+call.Receiver)
+	}
+
+	// Cache is unrelated to fiber, RequestCtx is named like a context
+	want := []string{"Get on RequestCtx"}
+	if !slices.Equal(receivers, want) {
+		t.Errorf("DetectSkippedCalls() = %v, want %v", receivers, want)
+	}
+}
+
+func TestIsFiberLikeType(t *testing.T) {
+	_, _, info := typeCheck(t, fiberHandlers, map[string]string{"github.com/gofiber/fiber/v2": fiberStub})
+
+	tests := map[string]bool{
+		"Cache":      false,
+		"RequestCtx": true,
+		"Wrapper":    true,
+		"User":       false,
+	}
+	checked := 0
+	for ident, obj := range info.Defs {
+		typeName, ok := obj.(*types.TypeName)
+		want, tested := tests[ident.Name]
+		if !ok || !tested {
+			continue
+		}
+		checked++
+		if got := isFiberLikeType(typeName.Type()); got != want {
+			t.Errorf("isFiberLikeType(%s) = %v, want %v", ident.Name, got, want)
+		}
+	}
+	if checked != len(tests) {
+		t.Errorf("checked %d types, want %d", checked, len(tests))
+	}
+}
@@ -0,0 +1,435 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// DefaultFramework is the framework used when none is selected
+const DefaultFramework = "gin"
+
+// FrameworkAdapter describes how the handlers of one web framework read the
+// request and write the response, so that annotation parsing can be shared by
+// every framework while auto-detection and path normalisation are framework
+// specific. Adapters for other routers can be added with RegisterFramework or
+// passed directly in Options.Adapter.
+type FrameworkAdapter interface {
+	// Name is the identifier used to select the adapter, e.g. "gin"
+	Name() string
+	// IsHandler reports whether fn is a handler, or returns one, so that its body is auto-detected
+	IsHandler(fn *ast.FuncDecl, info *types.Info) bool
+	// DetectParameters returns the path, query and header parameters read by fn
+	DetectParameters(fn *ast.FuncDecl, info *types.Info) []Parameter
+	// DetectRequestBody returns the model names fn decodes the request body into
+	DetectRequestBody(fn *ast.FuncDecl, info *types.Info) []string
+	// DetectResponses returns the response model per status code written by fn
+	DetectResponses(fn *ast.FuncDecl, info *types.Info) map[string]string
+	// DetectHeaders returns the response headers set by fn
+	DetectHeaders(fn *ast.FuncDecl, info *types.Info) []Header
+	// DetectSkippedCalls returns accessor-like calls in fn made on other receivers
+	DetectSkippedCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet) []SkippedCall
+	// DetectRegistrations returns the routes registered with the router in pkgs.
+	// All loaded packages are passed at once so that routers handed between
+	// functions and packages can be followed.
+	DetectRegistrations(pkgs []Package) []Registration
+	// NormalizePath converts a framework route path into an OpenAPI path template,
+	// returning any regular expression constraints keyed by path parameter name
+	NormalizePath(path string) (string, map[string]string)
+}
+
+// Package is a type-checked Go package of the project, as passed to DetectRegistrations
+type Package struct {
+	Path      string // import path
+	Fset      *token.FileSet
+	Syntax    []*ast.File
+	TypesInfo *types.Info
+}
+
+// adapterPackages converts loaded packages into the packages passed to adapters
+func adapterPackages(pkgs []*packages.Package) []Package {
+	converted := make([]Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		converted = append(converted, Package{
+			Path:      pkg.PkgPath,
+			Fset:      pkg.Fset,
+			Syntax:    pkg.Syntax,
+			TypesInfo: pkg.TypesInfo,
+		})
+	}
+	return converted
+}
+
+// Registration is a route registered with a router in code, such as
+// mux.HandleFunc("GET /users/{id}", GetUser)
+type Registration struct {
+	Method      string       // lower case HTTP method, e.g. "get"
+	Path        string       // route path in the framework's syntax, including group prefixes
+	Handler     types.Object // declaration of the handler function or method
+	Annotations []string     // group-level annotations, e.g. "@Tags users", applied to the route
+}
+
+var (
+	frameworksMu sync.RWMutex
+	frameworks   = map[string]FrameworkAdapter{}
+)
+
+func init() {
+	for _, adapter := range []FrameworkAdapter{ginAdapter{}, echoAdapter{}, chiAdapter{}, httpAdapter{}, fiberAdapter{}} {
+		RegisterFramework(adapter)
+	}
+}
+
+// RegisterFramework makes adapter selectable by its name, replacing any
+// adapter registered under the same name
+func RegisterFramework(adapter FrameworkAdapter) {
+	frameworksMu.Lock()
+	defer frameworksMu.Unlock()
+	frameworks[strings.ToLower(adapter.Name())] = adapter
+}
+
+// Frameworks returns the names of the registered frameworks
+func Frameworks() []string {
+	frameworksMu.RLock()
+	defer frameworksMu.RUnlock()
+
+	names := make([]string, 0, len(frameworks))
+	for name := range frameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupFramework returns the adapter registered under name, or the gin adapter if name is empty
+func LookupFramework(name string) (FrameworkAdapter, error) {
+	if name == "" {
+		name = DefaultFramework
+	}
+
+	frameworksMu.RLock()
+	adapter, ok := frameworks[strings.ToLower(name)]
+	frameworksMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported framework %q (supported: %s)", name, strings.Join(Frameworks(), ", "))
+	}
+	return adapter, nil
+}
+
+// hasHandlerParam reports whether fn takes a parameter matching isContext, or
+// returns a function that does, as handler factories like func (h *H) Get() gin.HandlerFunc do
+func hasHandlerParam(fn *ast.FuncDecl, info *types.Info, isContext func(types.Type) bool) bool {
+	obj, ok := info.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := obj.Type().(*types.Signature)
+	for i := 0; i < sig.Params().Len(); i++ {
+		if isContext(sig.Params().At(i).Type()) {
+			return true
+		}
+	}
+
+	for i := 0; i < sig.Results().Len(); i++ {
+		result, ok := sig.Results().At(i).Type().Underlying().(*types.Signature)
+		if !ok {
+			continue
+		}
+		for j := 0; j < result.Params().Len(); j++ {
+			if isContext(result.Params().At(j).Type()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// inspectSelectorCalls calls visit for every call of the form x.Name(...) in fn
+func inspectSelectorCalls(fn *ast.FuncDecl, visit func(call *ast.CallExpr, sel *ast.SelectorExpr)) {
+	if fn.Body == nil {
+		return
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			visit(call, sel)
+		}
+		return true
+	})
+}
+
+// inspectStatusCalls calls visit for every call of the form x.Name(...) in fn,
+// in source order, with the response status in effect at the call. A call for
+// which visit returns another status sets it for the rest of the statement
+// list the call is in, including the blocks nested in it, but not for the
+// enclosing blocks: the status of an early-return error branch does not leak
+// into the success path after it.
+func inspectStatusCalls(fn *ast.FuncDecl, status string, visit func(call *ast.CallExpr, sel *ast.SelectorExpr, status string) string) {
+	if fn.Body == nil {
+		return
+	}
+
+	var inspectList func(stmts []ast.Stmt, status string)
+	inspectList = func(stmts []ast.Stmt, status string) {
+		for _, stmt := range stmts {
+			ast.Inspect(stmt, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.BlockStmt:
+					inspectList(n.List, status)
+					return false
+				case *ast.CaseClause:
+					inspectList(n.Body, status)
+					return false
+				case *ast.CommClause:
+					inspectList(n.Body, status)
+					return false
+				case *ast.CallExpr:
+					if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+						status = visit(n, sel, status)
+					}
+				}
+				return true
+			})
+		}
+	}
+	inspectList(fn.Body.List, status)
+}
+
+// detectSkippedMethodCalls reports calls in fn to one of methods that are made
+// on a receiver for which isContextCall returns false. When isCandidate is not
+// nil, only receivers of the types it accepts are reported.
+func detectSkippedMethodCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet, methods map[string]bool, isContextCall func(*ast.SelectorExpr, *types.Info) bool, isCandidate func(types.Type) bool) []SkippedCall {
+	var skipped []SkippedCall
+	pkg := funcPackage(fn, info)
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		if !methods[sel.Sel.Name] {
+			return
+		}
+
+		// Only method calls are of interest, not package functions like url.Query
+		selection, ok := info.Selections[sel]
+		if !ok || selection.Kind() != types.MethodVal || isContextCall(sel, info) {
+			return
+		}
+		if isCandidate != nil && !isCandidate(selection.Recv()) {
+			return
+		}
+
+		skipped = append(skipped, SkippedCall{
+			Method:   sel.Sel.Name,
+			Receiver: types.TypeString(selection.Recv(), packageQualifier(pkg)),
+			Position: fset.Position(call.Pos()).String(),
+		})
+	})
+
+	return skipped
+}
+
+// isMethodOf reports whether sel selects a method declared on pkgPath.typeName,
+// either directly or promoted through an embedded field
+func isMethodOf(sel *ast.SelectorExpr, info *types.Info, pkgPath, typeName string) bool {
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+
+	method, ok := selection.Obj().(*types.Func)
+	if !ok {
+		return false
+	}
+
+	recv := method.Type().(*types.Signature).Recv()
+	return recv != nil && isNamedType(recv.Type(), pkgPath, typeName)
+}
+
+// isRequestHeaderGet reports whether sel is r.Header.Get on a *http.Request
+func isRequestHeaderGet(sel *ast.SelectorExpr, info *types.Info) bool {
+	if sel.Sel.Name != "Get" {
+		return false
+	}
+
+	field, ok := ast.Unparen(sel.X).(*ast.SelectorExpr)
+	if !ok || field.Sel.Name != "Header" {
+		return false
+	}
+	return isNamedType(info.TypeOf(field.X), "net/http", "Request")
+}
+
+// isResponseHeaderSet reports whether sel is w.Header().Set or w.Header().Add,
+// where Header is a method returning http.Header such as http.ResponseWriter's
+func isResponseHeaderSet(sel *ast.SelectorExpr, info *types.Info) bool {
+	if sel.Sel.Name != "Set" && sel.Sel.Name != "Add" {
+		return false
+	}
+
+	call, ok := ast.Unparen(sel.X).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	headerSel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || headerSel.Sel.Name != "Header" {
+		return false
+	}
+
+	selection, ok := info.Selections[headerSel]
+	return ok && selection.Kind() == types.MethodVal && isNamedType(info.TypeOf(call), "net/http", "Header")
+}
+
+// inspectPackages calls visit for every node of every file in pkgs, like ast.Inspect
+func inspectPackages(pkgs []Package, visit func(n ast.Node, info *types.Info) bool) {
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				return visit(n, pkg.TypesInfo)
+			})
+		}
+	}
+}
+
+// handlerObject resolves a handler expression passed to a router, such as
+// GetUser, h.GetUser, http.HandlerFunc(GetUser) or the handler factory call
+// h.GetUser(), to its declaration
+func handlerObject(expr ast.Expr, info *types.Info) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		if fn, ok := info.Uses[e].(*types.Func); ok {
+			return fn
+		}
+	case *ast.SelectorExpr:
+		if fn, ok := info.Uses[e.Sel].(*types.Func); ok {
+			return fn
+		}
+	case *ast.CallExpr:
+		// Conversions like http.HandlerFunc(GetUser)
+		if len(e.Args) == 1 && info.Types[e.Fun].IsType() {
+			return handlerObject(e.Args[0], info)
+		}
+		// Factories returning the handler, like h.GetUser()
+		if len(e.Args) == 0 {
+			return handlerObject(e.Fun, info)
+		}
+	}
+	return nil
+}
+
+// isPackageFunc reports whether sel refers to a package-level function named
+// name in a package for which matchPkg returns true
+func isPackageFunc(sel *ast.SelectorExpr, info *types.Info, name string, matchPkg func(path string) bool) bool {
+	if sel.Sel.Name != name {
+		return false
+	}
+
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return false
+	}
+	return matchPkg(fn.Pkg().Path())
+}
+
+// applyPathPatterns copies regular expression constraints onto the matching path parameters
+func applyPathPatterns(params []Parameter, patterns map[string]string) {
+	for i, p := range params {
+		if pattern, ok := patterns[p.Name]; ok && p.In == "path" && p.Pattern == "" {
+			params[i].Pattern = pattern
+		}
+	}
+}
+
+// wildcardParamName maps the unnamed "*" wildcard of echo and chi routes to a named path parameter
+func wildcardParamName(name string) string {
+	if name == "*" {
+		return "wildcard"
+	}
+	return name
+}
+
+// detectResponseHeaderSet returns the headers set through w.Header().Set
+func detectResponseHeaderSet(fn *ast.FuncDecl, info *types.Info) []Header {
+	var headers []Header
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		if len(call.Args) == 2 && isResponseHeaderSet(sel, info) {
+			if name, ok := firstStringArg(call, info); ok {
+				headers = append(headers, responseHeader(name))
+			}
+		}
+	})
+
+	return headers
+}
+
+// firstStringArg returns the constant value of the first argument of call
+func firstStringArg(call *ast.CallExpr, info *types.Info) (string, bool) {
+	if len(call.Args) == 0 {
+		return "", false
+	}
+	return constantValue(call.Args[0], info)
+}
+
+func pathParameter(name string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "path",
+		Required:    true,
+		Schema:      "string",
+		Description: fmt.Sprintf("Path parameter '%s'", name),
+	}
+}
+
+func queryParameter(name string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Required:    false,
+		Schema:      "string",
+		Description: fmt.Sprintf("Query parameter '%s'", name),
+	}
+}
+
+// formParameter is a query parameter that is also read from a form body
+func formParameter(name string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Required:    false,
+		Schema:      "string",
+		Description: fmt.Sprintf("Form value '%s'", name),
+		Form:        true,
+	}
+}
+
+func headerParameter(name string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "header",
+		Required:    false,
+		Schema:      "string",
+		Description: fmt.Sprintf("Header '%s'", name),
+	}
+}
+
+func responseHeader(name string) Header {
+	return Header{
+		StatusCode:  "200",
+		Name:        name,
+		Type:        "string",
+		Required:    true,
+		Description: fmt.Sprintf("Header '%s'", name),
+	}
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// ginAdapter detects gin.Context accessors in func(c *gin.Context) handlers
+type ginAdapter struct{}
+
+func (ginAdapter) Name() string {
+	return "gin"
+}
+
+func (ginAdapter) IsHandler(fn *ast.FuncDecl, info *types.Info) bool {
+	return hasHandlerParam(fn, info, func(t types.Type) bool {
+		return isNamedType(t, ginPkgPath, "Context")
+	})
+}
+
+func (ginAdapter) DetectParameters(fn *ast.FuncDecl, info *types.Info) []Parameter {
+	parameters, _ := DetectParametersAndQuery(fn, info)
+	return parameters
+}
+
+func (ginAdapter) DetectRequestBody(fn *ast.FuncDecl, info *types.Info) []string {
+	modelMap, _ := DetectRequestBodyType(fn, info)
+
+	models := make([]string, 0, len(modelMap))
+	for model := range modelMap {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+	return models
+}
+
+func (ginAdapter) DetectResponses(fn *ast.FuncDecl, info *types.Info) map[string]string {
+	return DetectResponseModel(fn, info)
+}
+
+func (ginAdapter) DetectHeaders(fn *ast.FuncDecl, info *types.Info) []Header {
+	headers, _ := DetectHeaders(fn, info)
+	return headers
+}
+
+func (ginAdapter) DetectSkippedCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet) []SkippedCall {
+	return DetectSkippedCalls(fn, info, fset)
+}
+
+func (ginAdapter) NormalizePath(path string) (string, map[string]string) {
+	return normalizePath(path), nil
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestGinDetectModels(t *testing.T) {
+	const src = `package handlers
+
+import (
+	"net/http"
+
+	"example.com/app/api"
+	"example.com/app/store"
+	"github.com/gin-gonic/gin"
+)
+
+type User struct{ Email string }
+
+func CreateUser(c *gin.Context) {
+	var user store.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		c.JSON(http.StatusBadRequest, api.User{})
+		return
+	}
+	c.JSON(http.StatusCreated, &User{})
+}
+`
+	_, file, info := typeCheck(t, src, map[string]string{
+		ginPkgPath:              ginStub,
+		"example.com/app/api":   "package api\n\ntype User struct{ Name string }",
+		"example.com/app/store": "package store\n\ntype User struct{ ID string }",
+	})
+	fn := funcDecl(t, file, "CreateUser")
+
+	// Models are named by import path, so that the registry never has to pick
+	// between the packages declaring User
+	body, err := DetectRequestBodyType(fn, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"example.com/app/store.User": ""}; !maps.Equal(body, want) {
+		t.Errorf("DetectRequestBodyType() = %v, want %v", body, want)
+	}
+
+	responses := DetectResponseModel(fn, info)
+	want := map[string]string{"400": "example.com/app/api.User", "201": "example.com/handlers.User"}
+	if !maps.Equal(responses, want) {
+		t.Errorf("DetectResponseModel() = %v, want %v", responses, want)
+	}
+}
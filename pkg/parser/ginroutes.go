@@ -0,0 +1,460 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+	"strings"
+)
+
+const ginPkgPath = "github.com/gin-gonic/gin"
+
+// maxGinGroupsPerRouter bounds the groups tracked for one router, so that
+// recursive helpers building ever longer prefixes cannot grow without end
+const maxGinGroupsPerRouter = 32
+
+// ginRouteMethods maps gin's route registration methods to the HTTP method they register
+var ginRouteMethods = map[string]string{
+	"GET":     "get",
+	"POST":    "post",
+	"PUT":     "put",
+	"PATCH":   "patch",
+	"DELETE":  "delete",
+	"HEAD":    "head",
+	"OPTIONS": "options",
+	"Any":     "any",
+	"Handle":  "",
+}
+
+// DetectRegistrations finds r.GET("/user/:id", GetUser) style calls on a
+// *gin.Engine, *gin.RouterGroup or gin.IRoutes, as well as r.Handle("GET", ...).
+// The handler is the last argument, after any middleware. Group prefixes are
+// followed through variables, struct fields, function parameters and return
+// values, and the annotations in the comment above the statement creating a
+// group are carried to every route registered in it.
+func (ginAdapter) DetectRegistrations(pkgs []Package) []Registration {
+	a := newGinRouterAnalysis(pkgs)
+	a.propagate()
+
+	// Routers passed to functions that are never called in the analysed source are the root
+	a.seedUnknownParams()
+	a.propagate()
+
+	return a.registrations()
+}
+
+// ginGroup is a router value: the path prefix accumulated through Group calls
+// and the annotations of the statements that created them
+type ginGroup struct {
+	prefix      string
+	annotations []string
+}
+
+// ginFuncDecl is a function declaration together with its type information
+type ginFuncDecl struct {
+	decl ast.Node // *ast.FuncDecl, or *ast.GenDecl for package level variables
+	info *types.Info
+}
+
+// ginRouterAnalysis tracks which groups every router variable, field,
+// parameter and function result may hold
+type ginRouterAnalysis struct {
+	decls     []ginFuncDecl
+	declared  map[types.Object]bool
+	groups    map[types.Object][]ginGroup
+	groupDocs map[*ast.CallExpr][]string // annotations above the statement of a Group call
+	changed   bool
+}
+
+func newGinRouterAnalysis(pkgs []Package) *ginRouterAnalysis {
+	a := &ginRouterAnalysis{
+		declared:  make(map[types.Object]bool),
+		groups:    make(map[types.Object][]ginGroup),
+		groupDocs: make(map[*ast.CallExpr][]string),
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if d.Body == nil {
+						continue
+					}
+					a.declared[pkg.TypesInfo.Defs[d.Name]] = true
+					a.decls = append(a.decls, ginFuncDecl{decl: d, info: pkg.TypesInfo})
+				case *ast.GenDecl:
+					a.decls = append(a.decls, ginFuncDecl{decl: d, info: pkg.TypesInfo})
+				}
+			}
+			a.collectGroupDocs(pkg, file)
+		}
+	}
+
+	return a
+}
+
+// collectGroupDocs records the annotations in the comment above each statement
+// calling Group. The doc comment of the function making the call is not one of
+// them, since a router setup function registers routes of many groups.
+func (a *ginRouterAnalysis) collectGroupDocs(pkg Package, file *ast.File) {
+	for node, comments := range ast.NewCommentMap(pkg.Fset, file, file.Comments) {
+		switch node.(type) {
+		case *ast.AssignStmt, *ast.DeclStmt, *ast.ExprStmt, *ast.ReturnStmt, *ast.GenDecl:
+		default:
+			continue
+		}
+
+		var annotations []string
+		for _, comment := range comments {
+			if comment.End() < node.Pos() {
+				annotations = appendAnnotations(annotations, docAnnotations(comment))
+			}
+		}
+		if len(annotations) == 0 {
+			continue
+		}
+
+		ast.Inspect(node, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				return false
+			}
+			if call, ok := n.(*ast.CallExpr); ok {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Group" && isGinRouterCall(sel, pkg.TypesInfo) {
+					a.groupDocs[call] = annotations
+				}
+			}
+			return true
+		})
+	}
+}
+
+// propagate flows groups through assignments, calls and returns until nothing changes
+func (a *ginRouterAnalysis) propagate() {
+	for iteration := 0; iteration < 100; iteration++ {
+		a.changed = false
+		for _, d := range a.decls {
+			a.visit(d)
+		}
+		if !a.changed {
+			return
+		}
+	}
+}
+
+func (a *ginRouterAnalysis) visit(d ginFuncDecl) {
+	var results *types.Tuple
+	var fnObj types.Object
+	if fn, ok := d.decl.(*ast.FuncDecl); ok {
+		if obj, ok := d.info.Defs[fn.Name].(*types.Func); ok {
+			fnObj = obj
+			results = obj.Type().(*types.Signature).Results()
+		}
+	}
+
+	ast.Inspect(d.decl, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// Returns inside closures belong to the closure, not to the declaration
+			ast.Inspect(node.Body, func(inner ast.Node) bool {
+				if _, ok := inner.(*ast.ReturnStmt); ok {
+					return false
+				}
+				a.visitNode(inner, d)
+				return true
+			})
+			return false
+		case *ast.ReturnStmt:
+			if results != nil && len(node.Results) == results.Len() {
+				for i, result := range node.Results {
+					if isGinRouterType(results.At(i).Type()) {
+						a.add(fnObj, a.values(result, d))
+					}
+				}
+			}
+		}
+		a.visitNode(n, d)
+		return true
+	})
+}
+
+func (a *ginRouterAnalysis) visitNode(n ast.Node, d ginFuncDecl) {
+	switch node := n.(type) {
+	case *ast.AssignStmt:
+		if len(node.Lhs) != len(node.Rhs) {
+			return
+		}
+		for i, lhs := range node.Lhs {
+			if obj := exprObject(lhs, d.info); obj != nil {
+				a.add(obj, a.values(node.Rhs[i], d))
+			}
+		}
+	case *ast.ValueSpec:
+		if len(node.Names) != len(node.Values) {
+			return
+		}
+		for i, name := range node.Names {
+			if obj := d.info.Defs[name]; obj != nil {
+				a.add(obj, a.values(node.Values[i], d))
+			}
+		}
+	case *ast.CallExpr:
+		// Routers passed as arguments flow into the callee's parameters
+		callee, ok := calleeObject(node.Fun, d.info).(*types.Func)
+		if !ok || !a.declared[callee] {
+			return
+		}
+		params := callee.Type().(*types.Signature).Params()
+		for i, arg := range node.Args {
+			if i < params.Len() && isGinRouterType(params.At(i).Type()) {
+				a.add(params.At(i), a.values(arg, d))
+			}
+		}
+	}
+}
+
+// values returns the groups a router expression may evaluate to
+func (a *ginRouterAnalysis) values(expr ast.Expr, d ginFuncDecl) []ginGroup {
+	expr = ast.Unparen(expr)
+	if !isGinRouterType(d.info.TypeOf(expr)) {
+		return nil
+	}
+
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && isGinRouterCall(sel, d.info) {
+			parents := a.values(sel.X, d)
+			if sel.Sel.Name != "Group" {
+				// Router methods like Use return the router they are called on
+				return parents
+			}
+
+			relative := ""
+			if len(e.Args) > 0 {
+				relative, _ = constantValue(e.Args[0], d.info)
+			}
+
+			groups := make([]ginGroup, 0, len(parents))
+			for _, parent := range parents {
+				groups = append(groups, ginGroup{
+					prefix:      joinRoutePaths(parent.prefix, relative),
+					annotations: appendAnnotations(parent.annotations, a.groupDocs[e]),
+				})
+			}
+			return groups
+		}
+
+		// Routers returned by functions in the analysed source
+		if callee := calleeObject(e.Fun, d.info); callee != nil && a.declared[callee] {
+			return a.groups[callee]
+		}
+
+		// gin.Default(), gin.New() and other constructors create a root router
+		return []ginGroup{{prefix: "/"}}
+	case *ast.Ident, *ast.SelectorExpr:
+		return a.groups[exprObject(e, d.info)]
+	}
+
+	return []ginGroup{{prefix: "/"}}
+}
+
+// add records groups for obj, reporting whether anything new was learned
+func (a *ginRouterAnalysis) add(obj types.Object, groups []ginGroup) {
+	if obj == nil {
+		return
+	}
+
+	for _, g := range groups {
+		known := a.groups[obj]
+		if len(known) >= maxGinGroupsPerRouter {
+			return
+		}
+
+		duplicate := false
+		for _, k := range known {
+			if k.prefix == g.prefix && strings.Join(k.annotations, "\n") == strings.Join(g.annotations, "\n") {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			a.groups[obj] = append(known, g)
+			a.changed = true
+		}
+	}
+}
+
+// seedUnknownParams makes router parameters without any known caller the root router
+func (a *ginRouterAnalysis) seedUnknownParams() {
+	for _, d := range a.decls {
+		fn, ok := d.decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		obj, ok := d.info.Defs[fn.Name].(*types.Func)
+		if !ok {
+			continue
+		}
+
+		params := obj.Type().(*types.Signature).Params()
+		for i := 0; i < params.Len(); i++ {
+			if isGinRouterType(params.At(i).Type()) && len(a.groups[params.At(i)]) == 0 {
+				a.add(params.At(i), []ginGroup{{prefix: "/"}})
+			}
+		}
+	}
+}
+
+// registrations returns a registration per route call and group it may be made on
+func (a *ginRouterAnalysis) registrations() []Registration {
+	var registrations []Registration
+
+	for _, d := range a.decls {
+		ast.Inspect(d.decl, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			method, ok := ginRouteMethods[sel.Sel.Name]
+			if !ok || !isGinRouterCall(sel, d.info) {
+				return true
+			}
+
+			args := call.Args
+			if sel.Sel.Name == "Handle" {
+				if len(args) == 0 {
+					return true
+				}
+				if method, ok = constantValue(args[0], d.info); !ok {
+					return true
+				}
+				method = strings.ToLower(method)
+				args = args[1:]
+			}
+
+			if len(args) < 2 {
+				return true
+			}
+
+			relative, ok := constantValue(args[0], d.info)
+			if !ok {
+				return true
+			}
+
+			handler := handlerObject(args[len(args)-1], d.info)
+			if handler == nil {
+				return true
+			}
+
+			groups := a.values(sel.X, d)
+			if len(groups) == 0 {
+				groups = []ginGroup{{prefix: "/"}}
+			}
+
+			for _, g := range groups {
+				registrations = append(registrations, Registration{
+					Method:      method,
+					Path:        joinRoutePaths(g.prefix, relative),
+					Handler:     handler,
+					Annotations: g.annotations,
+				})
+			}
+			return true
+		})
+	}
+
+	return registrations
+}
+
+// isGinRouterCall reports whether sel is a method of a gin router
+func isGinRouterCall(sel *ast.SelectorExpr, info *types.Info) bool {
+	return isMethodOf(sel, info, ginPkgPath, "RouterGroup") ||
+		isMethodOf(sel, info, ginPkgPath, "IRoutes") ||
+		isMethodOf(sel, info, ginPkgPath, "IRouter")
+}
+
+// isGinRouterType reports whether t is *gin.Engine, *gin.RouterGroup, gin.IRouter or gin.IRoutes
+func isGinRouterType(t types.Type) bool {
+	for _, name := range []string{"Engine", "RouterGroup", "IRouter", "IRoutes"} {
+		if isNamedType(t, ginPkgPath, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinRoutePaths joins a group prefix and a relative path the way gin does
+func joinRoutePaths(prefix, relative string) string {
+	if relative == "" {
+		return prefix
+	}
+
+	joined := path.Join(prefix, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// exprObject returns the variable or field an identifier or selector refers to
+func exprObject(expr ast.Expr, info *types.Info) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return info.ObjectOf(e)
+	case *ast.SelectorExpr:
+		return info.ObjectOf(e.Sel)
+	}
+	return nil
+}
+
+// calleeObject returns the function or method called through fun
+func calleeObject(fun ast.Expr, info *types.Info) types.Object {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return info.Uses[f]
+	case *ast.SelectorExpr:
+		return info.Uses[f.Sel]
+	}
+	return nil
+}
+
+// docAnnotations returns the @ annotation lines of a doc comment
+func docAnnotations(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+
+	var annotations []string
+	for _, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if strings.HasPrefix(text, "@") {
+			annotations = append(annotations, text)
+		}
+	}
+	return annotations
+}
+
+// appendAnnotations appends the annotations of extra not already in base, without modifying base
+func appendAnnotations(base, extra []string) []string {
+	result := append([]string(nil), base...)
+	for _, annotation := range extra {
+		found := false
+		for _, existing := range result {
+			if existing == annotation {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, annotation)
+		}
+	}
+	return result
+}
@@ -0,0 +1,280 @@
+package parser
+
+import (
+	"go/ast"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ginStub declares the parts of gin used by the tests
+const ginStub = `package gin
+
+type Context struct{}
+
+func (c *Context) ShouldBindJSON(obj any) error { return nil }
+func (c *Context) JSON(code int, obj any)       {}
+
+type HandlerFunc func(*Context)
+
+type IRoutes interface {
+	Use(...HandlerFunc) IRoutes
+	Handle(string, string, ...HandlerFunc) IRoutes
+	Any(string, ...HandlerFunc) IRoutes
+	GET(string, ...HandlerFunc) IRoutes
+	POST(string, ...HandlerFunc) IRoutes
+}
+
+type IRouter interface {
+	IRoutes
+	Group(string, ...HandlerFunc) *RouterGroup
+}
+
+type RouterGroup struct{}
+
+func (g *RouterGroup) Use(...HandlerFunc) IRoutes                      { return g }
+func (g *RouterGroup) Group(string, ...HandlerFunc) *RouterGroup       { return g }
+func (g *RouterGroup) Handle(string, string, ...HandlerFunc) IRoutes   { return g }
+func (g *RouterGroup) Any(string, ...HandlerFunc) IRoutes              { return g }
+func (g *RouterGroup) GET(string, ...HandlerFunc) IRoutes              { return g }
+func (g *RouterGroup) POST(string, ...HandlerFunc) IRoutes             { return g }
+
+type Engine struct{ RouterGroup }
+
+func New() *Engine     { return &Engine{} }
+func Default() *Engine { return &Engine{} }
+`
+
+// ginHandlers declares the handlers the sources of TestGinRegistrations register
+const ginHandlers = `
+func GetUser(c *gin.Context)    {}
+func CreateUser(c *gin.Context) {}
+func Ping(c *gin.Context)       {}
+func Auth(c *gin.Context)       {}
+
+type UserHandler struct{}
+
+func (h *UserHandler) List(c *gin.Context) {}
+`
+
+func TestGinRegistrations(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string // method, path, handler and annotations of each registration
+	}{
+		{
+			name: "LocalVariables",
+			src: `
+func main() {
+	r := gin.Default()
+	api := r.Group("/api/v1")
+	users := api.Group("/users")
+	users.GET("/:id", GetUser)
+	r.GET("/ping", Ping)
+}`,
+			want: []string{"get /api/v1/users/:id GetUser", "get /ping Ping"},
+		},
+		{
+			name: "NestedGroupCalls",
+			src: `
+func main() {
+	r := gin.New()
+	r.Group("/api").Group("v1/").Group("/users").POST("", Auth, CreateUser)
+}`,
+			want: []string{"post /api/v1/users CreateUser"},
+		},
+		{
+			name: "FunctionParameters",
+			src: `
+func main() {
+	r := gin.New()
+	registerUsers(r.Group("/api"))
+}
+
+func registerUsers(rg *gin.RouterGroup) {
+	rg.GET("/users/:id", GetUser)
+}`,
+			want: []string{"get /api/users/:id GetUser"},
+		},
+		{
+			name: "ReturnValues",
+			src: `
+func main() {
+	r := gin.New()
+	v1 := apiGroup(r)
+	v1.GET("/users/:id", GetUser)
+}
+
+func apiGroup(r *gin.Engine) *gin.RouterGroup {
+	return r.Group("/api").Group("/v1")
+}`,
+			want: []string{"get /api/v1/users/:id GetUser"},
+		},
+		{
+			name: "StructFields",
+			src: `
+type server struct {
+	api *gin.RouterGroup
+}
+
+func main() {
+	r := gin.New()
+	s := &server{}
+	s.api = r.Group("/api")
+	s.routes()
+}
+
+func (s *server) routes() {
+	s.api.GET("/users/:id", GetUser)
+}`,
+			want: []string{"get /api/users/:id GetUser"},
+		},
+		{
+			name: "MiddlewareChain",
+			src: `
+func main() {
+	r := gin.New()
+	r.Group("/api").Use(Auth).GET("/ping", Ping)
+}`,
+			want: []string{"get /api/ping Ping"},
+		},
+		{
+			name: "HandleAndAny",
+			src: `
+func main() {
+	r := gin.New()
+	api := r.Group("/api")
+	api.Handle("PATCH", "/users/:id", GetUser)
+	api.Any("/ping", Ping)
+	api.Handle(method(), "/skipped", Ping)
+}
+
+func method() string { return "GET" }`,
+			want: []string{"any /api/ping Ping", "patch /api/users/:id GetUser"},
+		},
+		{
+			name: "MethodValues",
+			src: `
+func main() {
+	r := gin.New()
+	h := &UserHandler{}
+	r.GET("/users", h.List)
+}`,
+			want: []string{"get /users List"},
+		},
+		{
+			name: "SeedUnknownParams",
+			src: `
+func Register(rg *gin.RouterGroup) {
+	rg.GET("/ping", Ping)
+	users := rg.Group("/users")
+	users.GET("/:id", GetUser)
+}`,
+			want: []string{"get /ping Ping", "get /users/:id GetUser"},
+		},
+		{
+			name: "GroupAnnotations",
+			src: `
+func main() {
+	r := gin.New()
+
+	// @Tags users
+	// @Security BearerAuth
+	users := r.Group("/users")
+	users.GET("/:id", GetUser)
+
+	// Admin routes
+	// @Tags admin
+	admin := users.Group("/admin")
+	admin.POST("", CreateUser)
+}`,
+			want: []string{
+				"get /users/:id GetUser [@Tags users|@Security BearerAuth]",
+				"post /users/admin CreateUser [@Tags users|@Security BearerAuth|@Tags admin]",
+			},
+		},
+		{
+			name: "SetupFunctionDocIsNotAGroupAnnotation",
+			src: `
+// @Tags internal
+// @Deprecated
+func setupRoutes(r *gin.Engine) {
+	api := r.Group("/api")
+	api.GET("/ping", Ping)
+	r.GET("/users/:id", GetUser)
+}
+
+func main() {
+	setupRoutes(gin.New())
+}`,
+			want: []string{"get /api/ping Ping", "get /users/:id GetUser"},
+		},
+		{
+			name: "PackageLevelGroup",
+			src: `
+var engine = gin.New()
+
+// @Tags api
+var api = engine.Group("/api")
+
+func main() {
+	api.GET("/ping", Ping)
+}`,
+			want: []string{"get /api/ping Ping [@Tags api]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ginRegistrations(t, tt.src)
+			want := slices.Sorted(slices.Values(tt.want))
+			if !slices.Equal(got, want) {
+				t.Errorf("DetectRegistrations() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGinRegistrationsGroupCap(t *testing.T) {
+	// A recursive helper builds ever longer prefixes
+	got := ginRegistrations(t, `
+func main() {
+	nest(gin.New().Group("/api"))
+}
+
+func nest(rg *gin.RouterGroup) {
+	rg.GET("/ping", Ping)
+	nest(rg.Group("/n"))
+}`)
+
+	if len(got) != maxGinGroupsPerRouter {
+		t.Fatalf("registrations = %d, want one per tracked group (%d)", len(got), maxGinGroupsPerRouter)
+	}
+	for i := range maxGinGroupsPerRouter {
+		want := "get /api" + strings.Repeat("/n", i) + "/ping Ping"
+		if !slices.Contains(got, want) {
+			t.Errorf("registrations lack %q", want)
+		}
+	}
+}
+
+// ginRegistrations detects the gin registrations of the handlers file made of
+// src and ginHandlers, formatted as method, path, handler and annotations
+func ginRegistrations(t *testing.T, src string) []string {
+	t.Helper()
+
+	fset, file, info := typeCheck(t, "package handlers\n\nimport \"github.com/gin-gonic/gin\"\n"+src+"\n"+ginHandlers,
+		map[string]string{ginPkgPath: ginStub})
+	pkgs := []Package{{Path: "example.com/handlers", Fset: fset, Syntax: []*ast.File{file}, TypesInfo: info}}
+
+	var got []string
+	for _, reg := range (ginAdapter{}).DetectRegistrations(pkgs) {
+		s := reg.Method + " " + reg.Path + " " + reg.Handler.Name()
+		if len(reg.Annotations) > 0 {
+			s += " [" + strings.Join(reg.Annotations, "|") + "]"
+		}
+		got = append(got, s)
+	}
+	slices.Sort(got)
+	return got
+}
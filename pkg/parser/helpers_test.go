@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// stubImporter imports the stub packages given as source, keyed by import
+// path, and the standard library from source
+type stubImporter struct {
+	fset     *token.FileSet
+	stubs    map[string]string
+	imported map[string]*types.Package
+	std      types.Importer
+}
+
+func (im *stubImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := im.imported[path]; ok {
+		return pkg, nil
+	}
+	src, ok := im.stubs[path]
+	if !ok {
+		return im.std.Import(path)
+	}
+
+	file, err := parser.ParseFile(im.fset, path+"/stub.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	conf := types.Config{Importer: im}
+	pkg, err := conf.Check(path, im.fset, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, err
+	}
+	im.imported[path] = pkg
+	return pkg, nil
+}
+
+// typeCheck parses and type-checks src, with the stub packages available to it
+func typeCheck(t *testing.T, src string, stubs map[string]string) (*token.FileSet, *ast.File, *types.Info) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "handlers.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{Importer: &stubImporter{
+		fset:     fset,
+		stubs:    stubs,
+		imported: make(map[string]*types.Package),
+		std:      importer.ForCompiler(fset, "source", nil),
+	}}
+	if _, err := conf.Check("example.com/handlers", fset, []*ast.File{file}, info); err != nil {
+		t.Fatal(err)
+	}
+	return fset, file, info
+}
+
+// funcDecl returns the function declared as name in file
+func funcDecl(t *testing.T, file *ast.File, name string) *ast.FuncDecl {
+	t.Helper()
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == name {
+			return fn
+		}
+	}
+	t.Fatalf("function %s not found", name)
+	return nil
+}
+
+// writeModule writes the module example.com/app with files, keyed by slash
+// separated path, into a temporary directory and returns it
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/app\n\ngo 1.22\n"})
+	writeFiles(t, dir, files)
+	return dir
+}
+
+// writeFiles writes files, keyed by slash separated path, below dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package parser
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Lint rules, used as LintIssue.Rule
+const (
+	RuleUnknownAnnotation = "unknown-annotation"
+	RuleMissingValue      = "missing-value"
+	RuleFieldCount        = "field-count"
+	RuleMissingObject     = "missing-object"
+	RuleParamLocation     = "param-location"
+	RuleUnknownType       = "unknown-type"
+	RuleRequiredFlag      = "required-flag"
+	RuleStatusCode        = "status-code"
+	RuleRouterMethod      = "router-method"
+	RuleRouterPath        = "router-path"
+	RuleRouterOption      = "router-option"
+	RuleUnknownModel      = "unknown-model"
+)
+
+// LintRules describes every lint rule, keyed by rule id
+var LintRules = map[string]string{
+	RuleUnknownAnnotation: "Annotation name is misspelled or wrongly cased, e.g. @Sucess or @success",
+	RuleMissingValue:      "Annotation has no value, e.g. @Summary without text",
+	RuleFieldCount:        "Annotation has too few or too many fields, e.g. @Param id path string",
+	RuleMissingObject:     "Model is not preceded by {object}, e.g. @RequestBody UserRequest true",
+	RuleParamLocation:     "@Param location is not path, query, header or cookie",
+	RuleUnknownType:       "@Param or @Header type is not an OpenAPI type",
+	RuleRequiredFlag:      "Required flag is not true or false, or a path parameter is not required",
+	RuleStatusCode:        "Status code is not a valid HTTP status code, range such as 4XX or default",
+	RuleRouterMethod:      "@Router or @Webhook method is missing, not in brackets or unknown",
+	RuleRouterPath:        "@Router path does not start with /",
+	RuleRouterOption:      "Word after the @Router method is not deprecated or operationId=<id>",
+	RuleUnknownModel:      "Model is not declared in the linted packages",
+}
+
+// LintIssue is a malformed annotation found by Lint
+type LintIssue struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"` // error for annotations that are ignored or misread, warning otherwise
+	Message  string `json:"message"`
+	Fix      string `json:"fix,omitempty"` // Suggested annotation or action
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s [%s]", i.File, i.Line, i.Column, i.Message, i.Rule)
+}
+
+// annotationNames are the annotations understood by the parser
+var annotationNames = []string{
+	"@Summary", "@Description", "@Tags", "@Param", "@RequestBody", "@Success", "@Failure",
+	"@Header", "@Security", "@Router", "@Deprecated", "@Webhook",
+	"@GlobalTitle", "@GlobalVersion", "@GlobalDescription", "@GlobalLicense", "@GlobalLicenseIdentifier", "@GlobalLicenseURL",
+}
+
+var (
+	paramLocations = []string{"path", "query", "header", "cookie"}
+	schemaTypes    = []string{"string", "integer", "number", "boolean", "array", "object"}
+	routerMethods  = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "any"}
+
+	statusCodePattern = regexp.MustCompile(`^([1-5](\d\d|XX)|default)$`)
+)
+
+// goSchemaTypes suggests the OpenAPI type for Go type names used by mistake
+var goSchemaTypes = map[string]string{
+	"int": "integer", "int8": "integer", "int16": "integer", "int32": "integer", "int64": "integer",
+	"uint": "integer", "uint8": "integer", "uint16": "integer", "uint32": "integer", "uint64": "integer",
+	"float32": "number", "float64": "number", "float": "number", "bool": "boolean", "str": "string",
+}
+
+// Lint loads the packages below dir and checks the annotations in function
+// docs, including router group annotations, and the global metadata above
+// package clauses, without generating a spec. Issues are returned in file and line order.
+func Lint(dir string) ([]LintIssue, error) {
+	return LintWithOptions(dir, Options{})
+}
+
+// LintWithOptions is Lint for the packages and files selected by the Roots,
+// Include and Exclude options, as ParseDirectoryWithOptions selects them:
+// function docs are only linted in the documented files.
+func LintWithOptions(dir string, opts Options) ([]LintIssue, error) {
+	filter, err := newFileFilter(dir, opts.Include, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, _, err := loadPackages(dir, opts.Roots)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{models: packageModels(pkgs, filter)}
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			filename := pkg.Fset.Position(file.Package).Filename
+			if seen[filename] {
+				continue
+			}
+			seen[filename] = true
+
+			// Annotations are read from function docs, from comments in function
+			// bodies for router groups and, for global metadata, above the package clause
+			l.fset = pkg.Fset
+			var groups []*ast.CommentGroup
+			for _, group := range file.Comments {
+				if group.End() < file.Package || inFuncBody(file, group) {
+					groups = append(groups, group)
+				}
+			}
+			documented := filter.matches(filename)
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Doc != nil && documented {
+					groups = append(groups, fn.Doc)
+				}
+			}
+			for _, group := range groups {
+				for _, comment := range group.List {
+					l.lintComment(comment)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(l.issues, func(a, b LintIssue) int {
+		return cmp.Or(strings.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	return l.issues, nil
+}
+
+// inFuncBody reports whether group is inside the body of a function declared in file
+func inFuncBody(file *ast.File, group *ast.CommentGroup) bool {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil && fn.Body.Pos() < group.Pos() && group.End() < fn.Body.End() {
+			return true
+		}
+	}
+	return false
+}
+
+type linter struct {
+	fset    *token.FileSet
+	models  map[string]types.Type
+	comment *ast.Comment // Comment being linted
+	issues  []LintIssue
+}
+
+func (l *linter) report(rule, severity string, at int, fix, format string, args ...any) {
+	pos := l.fset.Position(l.comment.Slash + token.Pos(at))
+	l.issues = append(l.issues, LintIssue{
+		Rule:     rule,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Fix:      fix,
+		File:     pos.Filename,
+		Line:     pos.Line,
+		Column:   pos.Column,
+	})
+}
+
+func (l *linter) lintComment(comment *ast.Comment) {
+	if !strings.HasPrefix(comment.Text, "//") {
+		return
+	}
+	fields := annotationFields(comment.Text, len("//"))
+	if len(fields) == 0 || !strings.HasPrefix(fields[0].text, "@") {
+		return
+	}
+
+	l.comment = comment
+	name, args := fields[0], fields[1:]
+
+	switch name.text {
+	case "@Summary", "@Description", "@Tags", "@Security",
+		"@GlobalTitle", "@GlobalVersion", "@GlobalDescription", "@GlobalLicense", "@GlobalLicenseIdentifier", "@GlobalLicenseURL":
+		if len(args) == 0 {
+			l.report(RuleMissingValue, "error", name.offset, "", "%s has no value and is ignored", name.text)
+		}
+	case "@Param":
+		l.lintParam(name, args)
+	case "@RequestBody":
+		l.lintRequestBody(name, args)
+	case "@Success", "@Failure":
+		l.lintResponse(name, args)
+	case "@Header":
+		l.lintHeader(name, args)
+	case "@Router":
+		l.lintRouter(name, args)
+	case "@Webhook":
+		l.lintWebhook(name, args)
+	default:
+		if strings.EqualFold(name.text, "@Deprecated") {
+			if len(args) > 0 {
+				l.report(RuleFieldCount, "error", args[0].offset, "@Deprecated", "@Deprecated takes no arguments and is ignored with them")
+			}
+			return
+		}
+		if suggestion := closestAnnotation(name.text); suggestion != "" {
+			l.report(RuleUnknownAnnotation, "error", name.offset, suggestion+joinFields(args, ""),
+				"unknown annotation %s is ignored, did you mean %s?", name.text, suggestion)
+		}
+	}
+}
+
+func (l *linter) lintParam(name field, args []field) {
+	// Format: @Param name in type required "description"
+	if !hasArity(name.text, len(args)) {
+		fix := []string{"name", "query", "string", "false", `"Description"`}
+		for i, arg := range args {
+			fix[i] = arg.text
+		}
+		if fix[1] == "path" {
+			fix[3] = "true"
+		}
+		l.report(RuleFieldCount, "error", name.offset, "@Param "+strings.Join(fix, " "),
+			"@Param needs a name, location, type and required flag, found %d field(s), so it is ignored", len(args))
+		return
+	}
+
+	if !slices.Contains(paramLocations, args[1].text) {
+		l.report(RuleParamLocation, "error", args[1].offset, "use one of path, query, header or cookie",
+			"unknown parameter location %q", args[1].text)
+	}
+	l.lintType(args[2])
+	l.lintRequiredFlag(args[3])
+	if args[1].text == "path" && args[3].text == "false" {
+		l.report(RuleRequiredFlag, "warning", args[3].offset, "true", "path parameter %q must be required", args[0].text)
+	}
+}
+
+func (l *linter) lintRequestBody(name field, args []field) {
+	// Format: @RequestBody {object} ModelName required "description"
+	if len(args) == 0 || !strings.HasPrefix(args[0].text, "{") {
+		fix := []string{"{object}", "ModelName", "true", `"Description"`}
+		for i, arg := range args[:min(len(args), 2)] {
+			fix[i+1] = arg.text
+		}
+		if len(args) > 2 {
+			fix[3] = joinFields(args[2:], "")[1:]
+		}
+		l.report(RuleMissingObject, "error", name.offset, "@RequestBody "+strings.Join(fix, " "),
+			"@RequestBody must start with {object} before the model name, so it is ignored")
+		return
+	}
+
+	if !hasArity(name.text, len(args)) {
+		fix := []string{"{object}", "ModelName", "true", `"Description"`}
+		for i, arg := range args {
+			fix[i] = arg.text
+		}
+		l.report(RuleFieldCount, "error", name.offset, "@RequestBody "+strings.Join(fix, " "),
+			"@RequestBody needs {object}, a model, a required flag and a description, found %d field(s), so it is ignored", len(args))
+		return
+	}
+
+	l.lintModel(args[1])
+	l.lintRequiredFlag(args[2])
+}
+
+func (l *linter) lintResponse(name field, args []field) {
+	// Format: @Success 200 {object} ModelName "description" or @Success 200 "description"
+	if !hasArity(name.text, len(args)) {
+		code := "200"
+		if len(args) == 1 {
+			code = args[0].text
+		}
+		l.report(RuleFieldCount, "error", name.offset, name.text+" "+code+` "Description"`,
+			"%s needs a status code and a description, so it is ignored", name.text)
+		return
+	}
+
+	l.lintStatusCode(args[0])
+
+	switch {
+	case strings.HasPrefix(args[1].text, "{"):
+		if len(args) < 3 {
+			l.report(RuleFieldCount, "error", args[1].offset, name.text+" "+args[0].text+" "+args[1].text+` ModelName "Description"`,
+				"%s has %s without a model name", name.text, args[1].text)
+			return
+		}
+		l.lintModel(args[2])
+	case isIdentifier(args[1].text) && l.models[args[1].text] != nil:
+		l.report(RuleMissingObject, "error", args[1].offset, name.text+" "+args[0].text+" {object}"+joinFields(args[1:], ""),
+			"%s is read as the description, add {object} before the model name", args[1].text)
+	}
+}
+
+func (l *linter) lintHeader(name field, args []field) {
+	// Format: @Header 200 X-Header string required "description"
+	if !hasArity(name.text, len(args)) {
+		fix := []string{"200", "X-Header", "string", "false", `"Description"`}
+		for i, arg := range args {
+			fix[i] = arg.text
+		}
+		l.report(RuleFieldCount, "error", name.offset, "@Header "+strings.Join(fix, " "),
+			"@Header needs a status code, name, type, required flag and description, found %d field(s), so it is ignored", len(args))
+		return
+	}
+
+	l.lintStatusCode(args[0])
+	l.lintType(args[2])
+	l.lintRequiredFlag(args[3])
+}
+
+func (l *linter) lintRouter(name field, args []field) {
+	// Format: @Router /path [method] [deprecated] [operationId=name]
+	switch {
+	case len(args) == 0:
+		l.report(RuleMissingValue, "error", name.offset, "@Router /path [get]", "@Router has no path or method and is ignored")
+		return
+	case !hasArity(name.text, len(args)):
+		l.report(RuleRouterMethod, "error", name.offset, "@Router "+args[0].text+" [get]", "@Router has no [method] and is ignored")
+		return
+	}
+
+	path, method := args[0], args[1]
+	if strings.HasPrefix(path.text, "[") && strings.HasPrefix(method.text, "/") {
+		l.report(RuleRouterMethod, "error", path.offset, "@Router "+method.text+" "+path.text+joinFields(args[2:], ""),
+			"@Router takes the path before the [method]")
+		return
+	}
+
+	if !strings.HasPrefix(path.text, "/") {
+		l.report(RuleRouterPath, "error", path.offset, "@Router /"+path.text+joinFields(args[1:], ""), "route path %q must start with /", path.text)
+	}
+	l.lintMethod(name, path.text, method)
+
+	for _, option := range args[2:] {
+		switch {
+		case strings.EqualFold(option.text, "deprecated"):
+		case strings.HasPrefix(option.text, "operationId=") && option.text != "operationId=":
+		default:
+			l.report(RuleRouterOption, "error", option.offset, "use deprecated or operationId=<id>", "unknown @Router option %q is ignored", option.text)
+		}
+	}
+}
+
+func (l *linter) lintWebhook(name field, args []field) {
+	// Format: @Webhook name [method]
+	if !hasArity(name.text, len(args)) {
+		fix := "@Webhook name [post]"
+		if len(args) > 0 {
+			fix = "@Webhook " + args[0].text + " [post]"
+		}
+		l.report(RuleFieldCount, "error", name.offset, fix, "@Webhook needs a name and a [method], found %d field(s), so it is ignored", len(args))
+		return
+	}
+	l.lintMethod(name, args[0].text, args[1])
+}
+
+// lintMethod checks the bracketed method following target in a @Router or @Webhook
+func (l *linter) lintMethod(name field, target string, method field) {
+	inner := strings.ToLower(strings.Trim(method.text, "[]"))
+	if !strings.HasPrefix(method.text, "[") || !strings.HasSuffix(method.text, "]") {
+		l.report(RuleRouterMethod, "error", method.offset, name.text+" "+target+" ["+inner+"]", "method %q must be in brackets", method.text)
+		return
+	}
+	if !slices.Contains(routerMethods, inner) {
+		l.report(RuleRouterMethod, "error", method.offset, "use one of "+strings.Join(routerMethods, ", "), "unknown HTTP method %q", inner)
+	}
+}
+
+func (l *linter) lintType(t field) {
+	if slices.Contains(schemaTypes, t.text) {
+		return
+	}
+	fix := "use one of " + strings.Join(schemaTypes, ", ")
+	if suggestion, ok := goSchemaTypes[t.text]; ok {
+		fix = suggestion
+	}
+	l.report(RuleUnknownType, "error", t.offset, fix, "%q is not an OpenAPI type", t.text)
+}
+
+func (l *linter) lintRequiredFlag(required field) {
+	if required.text != "true" && required.text != "false" {
+		l.report(RuleRequiredFlag, "error", required.offset, "true or false", "required flag %q is read as false", required.text)
+	}
+}
+
+func (l *linter) lintStatusCode(code field) {
+	if !statusCodePattern.MatchString(code.text) {
+		l.report(RuleStatusCode, "error", code.offset, "use a status code such as 200, 4XX or default", "invalid status code %q", code.text)
+	}
+}
+
+func (l *linter) lintModel(model field) {
+	if _, ok := l.models[model.text]; !ok {
+		l.report(RuleUnknownModel, "warning", model.offset, "declare the type or fix its name",
+			"model %s is not declared in the linted packages", model.text)
+	}
+}
+
+// joinFields joins the words of fields, each preceded by a space, after prefix
+func joinFields(fields []field, prefix string) string {
+	for _, f := range fields {
+		prefix += " " + f.text
+	}
+	return prefix
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && r != '.' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// closestAnnotation returns the known annotation name is most likely a typo
+// of, or "" if it is not close to any, such as @author
+func closestAnnotation(name string) string {
+	best, bestDistance := "", 3
+	for _, known := range annotationNames {
+		if strings.EqualFold(name, known) {
+			return known
+		}
+		if d := editDistance(strings.ToLower(name), strings.ToLower(known)); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// lintLine lints a single annotation comment, with User declared as a model
+func lintLine(t *testing.T, line string) []LintIssue {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "handlers.go", "package handlers\n\n"+line+"\nfunc Handler() {}\n", parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := types.NewNamed(types.NewTypeName(token.NoPos, nil, "User", nil), types.NewStruct(nil, nil), nil)
+	l := &linter{fset: fset, models: map[string]types.Type{"User": user}}
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Doc != nil {
+			for _, comment := range fn.Doc.List {
+				l.lintComment(comment)
+			}
+		}
+	}
+	return l.issues
+}
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string // rules of the issues
+	}{
+		// Valid annotations
+		{`// @Summary Get user`, nil},
+		{`// @Param id path string true "User ID"`, nil},
+		{`// @RequestBody {object} User true "User"`, nil},
+		{`// @Success 200 {object} User "OK"`, nil},
+		{`// @Failure 4XX "Client error"`, nil},
+		{`// @Failure default "Unexpected error"`, nil},
+		{`// @Header 200 X-Rate-Limit integer false "Limit"`, nil},
+		{`// @Router /users/{id} [get] deprecated operationId=getUser`, nil},
+		{`// @Webhook userCreated [post]`, nil},
+		{`// @Deprecated`, nil},
+		{`// @author someone`, nil},
+
+		{`// @Sucess 200 "OK"`, []string{RuleUnknownAnnotation}},
+		{`// @summary Get user`, []string{RuleUnknownAnnotation}},
+		{`// @Summary`, []string{RuleMissingValue}},
+		{`// @Router`, []string{RuleMissingValue}},
+		{`// @Param id path string`, []string{RuleFieldCount}},
+		{`// @RequestBody {object} User`, []string{RuleFieldCount}},
+		{`// @Success 200`, []string{RuleFieldCount}},
+		{`// @Success 200 {object}`, []string{RuleFieldCount}},
+		{`// @Header 200 X-Rate-Limit integer`, []string{RuleFieldCount}},
+		{`// @Webhook userCreated`, []string{RuleFieldCount}},
+		{`// @Deprecated since v2`, []string{RuleFieldCount}},
+		{`// @RequestBody User true "User"`, []string{RuleMissingObject}},
+		{`// @Success 200 User "OK"`, []string{RuleMissingObject}},
+		{`// @Param id body string true "ID"`, []string{RuleParamLocation}},
+		{`// @Param id query int false "ID"`, []string{RuleUnknownType}},
+		{`// @Header 200 X-Rate-Limit int false "Limit"`, []string{RuleUnknownType}},
+		{`// @Param id query string yes "ID"`, []string{RuleRequiredFlag}},
+		{`// @Param id path string false "ID"`, []string{RuleRequiredFlag}},
+		{`// @Success 20 "OK"`, []string{RuleStatusCode}},
+		{`// @Failure Default "Unexpected error"`, []string{RuleStatusCode}},
+		{`// @Header 600 X-Rate-Limit integer false "Limit"`, []string{RuleStatusCode}},
+		{`// @Router /users`, []string{RuleRouterMethod}},
+		{`// @Router [get] /users`, []string{RuleRouterMethod}},
+		{`// @Router /users get`, []string{RuleRouterMethod}},
+		{`// @Router /users [fetch]`, []string{RuleRouterMethod}},
+		{`// @Webhook userCreated post`, []string{RuleRouterMethod}},
+		{`// @Router users [get]`, []string{RuleRouterPath}},
+		{`// @Router /users [get] internal`, []string{RuleRouterOption}},
+		{`// @Success 200 {object} Account "OK"`, []string{RuleUnknownModel}},
+		{`// @RequestBody {object} Account true "Account"`, []string{RuleUnknownModel}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			var got []string
+			for _, issue := range lintLine(t, tt.line) {
+				got = append(got, issue.Rule)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Lint(%s) rules = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLintMatchesParser checks that the annotations Lint reports as ignored
+// for their field count are the ones the parser ignores
+func TestLintMatchesParser(t *testing.T) {
+	lines := []string{
+		`@Param id path string`, `@Param id path string true`,
+		`@RequestBody {object} User true`, `@RequestBody {object} User true "User"`,
+		`@Success 200`, `@Success 200 "OK"`,
+		`@Failure 400`, `@Failure 400 "Bad request"`,
+		`@Header 200 X-Limit integer true`, `@Header 200 X-Limit integer true "Limit"`,
+		`@Webhook userCreated`, `@Webhook userCreated [post]`, `@Webhook userCreated [post] extra`,
+	}
+	for _, line := range lines {
+		t.Run(line, func(t *testing.T) {
+			doc := RouteDoc{Responses: make(map[string]Response)}
+			applyAnnotation(&doc, line)
+			read := len(doc.Params) > 0 || doc.RequestBody != nil || len(doc.Responses) > 0 || len(doc.Headers) > 0 || doc.Webhook != ""
+
+			reported := slices.ContainsFunc(lintLine(t, "// "+line), func(issue LintIssue) bool { return issue.Rule == RuleFieldCount })
+			if read == reported {
+				t.Errorf("parser reads %s: %v, lint reports its field count: %v", line, read, reported)
+			}
+		})
+	}
+}
+
+func TestLintWithOptions(t *testing.T) {
+	handler := func(pkg string) string {
+		return "package " + pkg + "\n\n// @Sucess 200 \"OK\"\nfunc Handler() {}\n"
+	}
+	dir := writeModule(t, map[string]string{
+		"main.go":                 handler("main"),
+		"handlers/users.go":       handler("handlers"),
+		"handlers/users_mock.go":  "package handlers\n\n// @Sucess 200 \"OK\"\nfunc MockHandler() {}\n",
+		"internal/admin/admin.go": handler("admin"),
+		"tools/generate/main.go":  handler("main"),
+	})
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string // files with issues, relative to dir
+	}{
+		{"Everything", Options{}, []string{"handlers/users.go", "handlers/users_mock.go", "internal/admin/admin.go", "main.go", "tools/generate/main.go"}},
+		{"Roots", Options{Roots: []string{"handlers", "internal"}}, []string{"handlers/users.go", "handlers/users_mock.go", "internal/admin/admin.go"}},
+		{"Include", Options{Include: []string{"handlers/**"}}, []string{"handlers/users.go", "handlers/users_mock.go"}},
+		{"Exclude", Options{Exclude: []string{"**/*_mock.go", "tools"}}, []string{"handlers/users.go", "internal/admin/admin.go", "main.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := LintWithOptions(dir, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, issue := range issues {
+				rel, err := filepath.Rel(dir, issue.File)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("files with issues = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
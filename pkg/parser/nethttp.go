@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// httpAdapter detects net/http accessors in func(w http.ResponseWriter, r *http.Request)
+// handlers registered on a Go 1.22+ http.ServeMux with "METHOD /path/{name}" patterns
+type httpAdapter struct{}
+
+func (httpAdapter) Name() string {
+	return "http"
+}
+
+func (httpAdapter) IsHandler(fn *ast.FuncDecl, info *types.Info) bool {
+	return hasHandlerParam(fn, info, isHTTPRequest)
+}
+
+func (httpAdapter) DetectParameters(fn *ast.FuncDecl, info *types.Info) []Parameter {
+	var parameters []Parameter
+
+	inspectSelectorCalls(fn, func(call *ast.CallExpr, sel *ast.SelectorExpr) {
+		switch {
+		case sel.Sel.Name == "PathValue" && isMethodOf(sel, info, "net/http", "Request"):
+			// Detect path parameters (e.g., r.PathValue("id"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, pathParameter(name))
+			}
+		case sel.Sel.Name == "FormValue" && isMethodOf(sel, info, "net/http", "Request"):
+			// Detect query or form body parameters (e.g., r.FormValue("name"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, formParameter(name))
+			}
+		case isQueryValuesGet(sel, info):
+			// Detect query parameters (e.g., r.URL.Query().Get("name"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, queryParameter(name))
+			}
+		case isRequestHeaderGet(sel, info):
+			// Detect headers (e.g., r.Header.Get("X-Correlation-ID"))
+			if name, ok := firstStringArg(call, info); ok {
+				parameters = append(parameters, headerParameter(name))
+			}
+		}
+	})
+
+	return parameters
+}
+
+func (httpAdapter) DetectRequestBody(fn *ast.FuncDecl, info *types.Info) []string {
+	return detectJSONDecode(fn, info)
+}
+
+func (httpAdapter) DetectResponses(fn *ast.FuncDecl, info *types.Info) map[string]string {
+	return detectJSONEncode(fn, info)
+}
+
+func (httpAdapter) DetectHeaders(fn *ast.FuncDecl, info *types.Info) []Header {
+	return detectResponseHeaderSet(fn, info)
+}
+
+// DetectSkippedCalls returns nothing: net/http accessors are recognised by their receiver type
+func (httpAdapter) DetectSkippedCalls(fn *ast.FuncDecl, info *types.Info, fset *token.FileSet) []SkippedCall {
+	return nil
+}
+
+// DetectRegistrations finds mux.HandleFunc/mux.Handle calls on an *http.ServeMux
+// and the http.HandleFunc/http.Handle package functions
+func (httpAdapter) DetectRegistrations(pkgs []Package) []Registration {
+	var registrations []Registration
+
+	inspectPackages(pkgs, func(n ast.Node, info *types.Info) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (sel.Sel.Name != "HandleFunc" && sel.Sel.Name != "Handle") {
+			return true
+		}
+
+		isNetHTTP := func(path string) bool { return path == "net/http" }
+		if !isMethodOf(sel, info, "net/http", "ServeMux") && !isPackageFunc(sel, info, sel.Sel.Name, isNetHTTP) {
+			return true
+		}
+
+		pattern, ok := constantValue(call.Args[0], info)
+		if !ok {
+			return true
+		}
+
+		handler := handlerObject(call.Args[1], info)
+		if handler == nil {
+			return true
+		}
+
+		method, path := splitServeMuxPattern(pattern)
+		registrations = append(registrations, Registration{
+			Method:  method,
+			Path:    path,
+			Handler: handler,
+		})
+		return true
+	})
+
+	return registrations
+}
+
+// NormalizePath turns {name...} wildcards into {name} and drops the {$} end anchor
+func (httpAdapter) NormalizePath(path string) (string, map[string]string) {
+	path = strings.TrimSuffix(path, "{$}")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}") {
+			segments[i] = strings.TrimSuffix(segment, "...}") + "}"
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// splitServeMuxPattern splits a ServeMux pattern "[METHOD ][HOST]/[PATH]" into
+// its lower case method and path. Patterns without a method match every
+// method and, like gin's Any, are documented for each of them.
+func splitServeMuxPattern(pattern string) (string, string) {
+	method := "any"
+	if m, rest, ok := strings.Cut(strings.TrimSpace(pattern), " "); ok {
+		method = strings.ToLower(m)
+		pattern = strings.TrimSpace(rest)
+	}
+
+	// Drop the host, if any
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+	return method, pattern
+}
@@ -0,0 +1,233 @@
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const loadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedSyntax |
+	packages.NeedTypes |
+	packages.NeedTypesInfo |
+	packages.NeedImports |
+	packages.NeedDeps
+
+// loadPackages loads every package below the roots of dir, or below dir when
+// there are none, with full type information. Packages with type errors are
+// still returned so that the parts which did type-check can be used, and their
+// errors are reported as diagnostics.
+func loadPackages(dir string, roots []string) ([]*packages.Package, Diagnostics, error) {
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  dir,
+	}
+
+	patterns := []string{"./..."}
+	if len(roots) > 0 {
+		patterns = nil
+		for _, root := range roots {
+			root = filepath.ToSlash(filepath.Clean(root))
+			if !filepath.IsAbs(root) && root != "." && !strings.HasPrefix(root, "../") {
+				root = "./" + root
+			}
+			patterns = append(patterns, strings.TrimSuffix(root, "/")+"/...")
+		}
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load packages in %s: %w", dir, err)
+	}
+
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].PkgPath < pkgs[j].PkgPath
+	})
+
+	return pkgs, packageDiagnostics(pkgs), nil
+}
+
+// packageDiagnostics reports the load and type errors of pkgs
+func packageDiagnostics(pkgs []*packages.Package) Diagnostics {
+	var diags Diagnostics
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			position := e.Pos
+			if position == "-" {
+				position = ""
+			}
+			diags.Warnf(CodePackageError, position, "", "package %s: %s", pkg.PkgPath, e.Msg)
+		}
+	}
+	return diags
+}
+
+// DiscoverModels loads the Go source below dir and returns every named type
+// declared at package level, keyed by its bare name ("UserResponse"), its
+// package-qualified name ("dto.UserResponse") and its import path qualified
+// name ("example.com/app/dto.UserResponse"). When two packages declare the
+// same bare or package-qualified name, the first package in import path order
+// wins; the generator reports the models looked up by such a name.
+func DiscoverModels(dir string) (map[string]types.Type, error) {
+	return DiscoverModelsWithOptions(dir, Options{})
+}
+
+// DiscoverModelsWithOptions is DiscoverModels for the packages below the roots
+// of opts and the packages they import from dir, leaving out the types
+// declared in files matched by opts.Exclude
+func DiscoverModelsWithOptions(dir string, opts Options) (map[string]types.Type, error) {
+	filter, err := newFileFilter(dir, nil, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	// Package errors are reported when parsing routes
+	pkgs, _, err := loadPackages(dir, opts.Roots)
+	if err != nil {
+		return nil, err
+	}
+
+	// Models are often declared outside the roots, in the packages their handlers import
+	loaded := make(map[string]bool)
+	var models []*packages.Package
+	packages.Visit(pkgs, func(pkg *packages.Package) bool {
+		if loaded[pkg.PkgPath] || !within(pkg.Dir, filter.dir) {
+			return false
+		}
+		loaded[pkg.PkgPath] = true
+		models = append(models, pkg)
+		return true
+	}, nil)
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].PkgPath < models[j].PkgPath
+	})
+	return packageModels(models, filter), nil
+}
+
+// packageModels returns the named types declared at package level in pkgs, as
+// DiscoverModels, leaving out those declared in the files filter excludes
+func packageModels(pkgs []*packages.Package, filter *fileFilter) map[string]types.Type {
+	models := make(map[string]types.Type)
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+			if filter != nil && pkg.Fset != nil && filter.excludes(pkg.Fset.Position(obj.Pos()).Filename) {
+				continue
+			}
+
+			models[pkg.PkgPath+"."+name] = obj.Type()
+			qualified := pkg.Types.Name() + "." + name
+			if _, exists := models[qualified]; !exists {
+				models[qualified] = obj.Type()
+			}
+			if _, exists := models[name]; !exists {
+				models[name] = obj.Type()
+			}
+		}
+	}
+
+	return models
+}
+
+// fileFilter selects the files documented by ParseDirectoryWithOptions with
+// include and exclude globs, matched against slash separated paths relative
+// to dir, or against absolute paths for absolute globs. A pattern matching a
+// directory matches every file below it.
+type fileFilter struct {
+	dir              string
+	include, exclude []string
+}
+
+func newFileFilter(dir string, include, exclude []string) (*fileFilter, error) {
+	for _, pattern := range slices.Concat(include, exclude) {
+		if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &fileFilter{dir: abs, include: include, exclude: exclude}, nil
+}
+
+// matches reports whether filename is included and not excluded
+func (f *fileFilter) matches(filename string) bool {
+	return (len(f.include) == 0 || f.matchAny(f.include, filename)) && !f.excludes(filename)
+}
+
+// excludes reports whether filename is excluded
+func (f *fileFilter) excludes(filename string) bool {
+	return f.matchAny(f.exclude, filename)
+}
+
+// matchAny reports whether filename, or a directory it is in, matches one of patterns
+func (f *fileFilter) matchAny(patterns []string, filename string) bool {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return false
+	}
+	rel, relErr := filepath.Rel(f.dir, abs)
+
+	for _, pattern := range patterns {
+		name := filepath.ToSlash(rel)
+		if filepath.IsAbs(filepath.FromSlash(pattern)) {
+			name = filepath.ToSlash(abs)
+		} else if relErr != nil {
+			continue
+		}
+		if matchPathGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathGlob reports whether the slash separated name, or a directory it is in, matches pattern
+func matchPathGlob(pattern, name string) bool {
+	segments := strings.Split(strings.Trim(name, "/"), "/")
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	for i := len(segments); i > 0; i-- {
+		if matchGlob(patternSegments, segments[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against pattern segments, where ** matches any number of segments
+func matchGlob(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlob(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
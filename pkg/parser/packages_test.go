@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFileFilter(t *testing.T) {
+	dir := t.TempDir()
+	abs := filepath.ToSlash(dir)
+
+	tests := []struct {
+		name             string
+		include, exclude []string
+		file             string
+		want             bool
+	}{
+		{"NoGlobs", nil, nil, "handlers/user.go", true},
+		{"Included", []string{"handlers/**"}, nil, "handlers/admin/user.go", true},
+		{"NotIncluded", []string{"handlers/**"}, nil, "models/user.go", false},
+		{"Excluded", nil, []string{"**/*_mock.go"}, "handlers/user_mock.go", false},
+		{"ExcludedDirectory", nil, []string{"internal"}, "internal/db/user.go", false},
+		{"AbsoluteIncluded", []string{abs + "/handlers/**"}, nil, "handlers/user.go", true},
+		{"AbsoluteNotIncluded", []string{abs + "/handlers/**"}, nil, "models/user.go", false},
+		{"AbsoluteExcluded", nil, []string{abs + "/**/*_mock.go"}, "handlers/user_mock.go", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newFileFilter(dir, tt.include, tt.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter.matches(filepath.Join(dir, filepath.FromSlash(tt.file))); got != tt.want {
+				t.Errorf("matches(%s) = %v, want %v", tt.file, got, tt.want)
+			}
+		})
+	}
+}
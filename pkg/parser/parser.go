@@ -2,11 +2,15 @@ package parser
 
 import (
 	"go/ast"
-	"go/parser"
 	"go/token"
+	"go/types"
+	"maps"
 	"os"
-	"path/filepath"
+	"slices"
 	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
 )
 
 type SecurityScheme struct {
@@ -43,9 +47,12 @@ func parseSecurityScheme(securityText string) SecurityScheme {
 }
 
 type GlobalMetadata struct {
-	GlobalTitle       string
-	GlobalVersion     string
-	GlobalDescription string
+	GlobalTitle             string
+	GlobalVersion           string
+	GlobalDescription       string
+	GlobalLicense           string // License name
+	GlobalLicenseIdentifier string // SPDX license expression, e.g. "Apache-2.0"
+	GlobalLicenseURL        string
 }
 
 type Parameter struct {
@@ -53,7 +60,12 @@ type Parameter struct {
 	In          string // path, query, header, cookie
 	Required    bool
 	Schema      string // string, integer, etc.
+	Pattern     string // Regular expression constraint, e.g. from chi's {id:[0-9]+}
 	Description string
+	// Form marks a query parameter that is also read from a form body, as by
+	// net/http's r.FormValue; for POST, PUT and PATCH it is documented as a
+	// field of an application/x-www-form-urlencoded request body instead
+	Form bool
 }
 
 type RequestBody struct {
@@ -78,6 +90,14 @@ type Header struct {
 	Description string
 }
 
+// SkippedCall is a call that looked like a framework accessor during
+// auto-detection but was made on an unrelated receiver
+type SkippedCall struct {
+	Method   string // e.g. "Query"
+	Receiver string // receiver type, e.g. "*sql.DB"
+	Position string // file:line:column of the call
+}
+
 type RouteDoc struct {
 	Summary         string
 	Description     string
@@ -90,10 +110,38 @@ type RouteDoc struct {
 	Headers         []Header
 	SecuritySchemes []SecurityScheme
 	Deprecated      bool
+	SkippedCalls    []SkippedCall
+	Routers         []Router // One per @Router line; Method and Path hold the first
+	Handler         string   // Name of the documented function
+	Func            string   // Documented function as runtime.FuncForPC names it, e.g. example.com/app/api.(*Server).GetUser
+	OperationID     string
+	Webhook         string // Name of the webhook documented by @Webhook; Path is empty
+	Position        string // file:line:column of the documented function
+}
+
+// Router is a single @Router line. Words after the method override the
+// handler's annotations for that route only, e.g.
+// "@Router /user/{id} [get] deprecated operationId=getLegacyUser"
+type Router struct {
+	Method      string
+	Path        string
+	Deprecated  bool
+	OperationID string
 }
 
+// ParseGlobalMetadata reads the @Global annotations above the package clause
+// of filePath, returning empty metadata if the file cannot be read
 func ParseGlobalMetadata(filePath string) GlobalMetadata {
-	src, _ := os.ReadFile(filePath)
+	metadata, _ := ReadGlobalMetadata(filePath)
+	return metadata
+}
+
+// ReadGlobalMetadata is ParseGlobalMetadata, returning the error if filePath cannot be read
+func ReadGlobalMetadata(filePath string) (GlobalMetadata, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return GlobalMetadata{}, err
+	}
 	lines := strings.Split(string(src), "\n")
 
 	metadata := GlobalMetadata{}
@@ -109,208 +157,561 @@ func ParseGlobalMetadata(filePath string) GlobalMetadata {
 		if strings.HasPrefix(line, "// @GlobalDescription ") {
 			metadata.GlobalDescription = strings.TrimSpace(strings.TrimPrefix(line, "// @GlobalDescription "))
 		}
+		if strings.HasPrefix(line, "// @GlobalLicense ") {
+			metadata.GlobalLicense = strings.TrimSpace(strings.TrimPrefix(line, "// @GlobalLicense "))
+		}
+		if strings.HasPrefix(line, "// @GlobalLicenseIdentifier ") {
+			metadata.GlobalLicenseIdentifier = strings.TrimSpace(strings.TrimPrefix(line, "// @GlobalLicenseIdentifier "))
+		}
+		if strings.HasPrefix(line, "// @GlobalLicenseURL ") {
+			metadata.GlobalLicenseURL = strings.TrimSpace(strings.TrimPrefix(line, "// @GlobalLicenseURL "))
+		}
 		if strings.HasPrefix(line, "package ") {
 			break // Stop after reaching package line
 		}
 	}
-	return metadata
+	return metadata, nil
+}
+
+// Options controls how ParseDirectoryWithOptions detects routes
+type Options struct {
+	// Framework selects the registered adapter for the web framework handlers
+	// are written for: gin (default), echo, chi, http, fiber or a custom one
+	Framework string
+	// Adapter, when set, is used instead of looking Framework up
+	Adapter FrameworkAdapter
+	// Strict turns warnings, such as a @Router not matching the handler's
+	// registration, into errors
+	Strict bool
+	// Roots are the directories, relative to the parsed directory or
+	// absolute, whose packages are loaded; all packages below it by default
+	Roots []string
+	// Include and Exclude are globs such as "internal/**" or "**/*_mock.go",
+	// matched against slash separated paths relative to the parsed directory,
+	// or against absolute paths for absolute globs, that select the files
+	// whose functions are documented. A glob matching a directory matches
+	// every file below it. DiscoverModelsWithOptions leaves out the types
+	// declared in excluded files.
+	Include []string
+	Exclude []string
 }
 
-// ParseDirectory parses all .go files in a folder and extracts annotations
+// ParseDirectory loads all packages below dir with full type information and
+// extracts annotations from every function declaration, assuming gin handlers.
+// Use ParseDirectoryWithOptions to receive the problems found as diagnostics.
 func ParseDirectory(dir string) ([]RouteDoc, error) {
-	var routes []RouteDoc
+	routes, _, err := ParseDirectoryWithOptions(dir, Options{})
+	return routes, err
+}
 
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if !strings.HasSuffix(path, ".go") || strings.Contains(path, "_test.go") {
-			return nil
+// ParseDirectoryWithOptions is ParseDirectory for the framework selected in
+// opts, returning the problems found as diagnostics. In strict mode every
+// diagnostic is an error and the error summarises them.
+func ParseDirectoryWithOptions(dir string, opts Options) ([]RouteDoc, Diagnostics, error) {
+	fw := opts.Adapter
+	if fw == nil {
+		var err error
+		if fw, err = LookupFramework(opts.Framework); err != nil {
+			return nil, nil, err
 		}
+	}
 
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
-		if err != nil {
-			return err
+	filter, err := newFileFilter(dir, opts.Include, opts.Exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkgs, diags, err := loadPackages(dir, opts.Roots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routes := parsePackages(fw, filter, pkgs, &diags)
+	if opts.Strict {
+		diags.Strict()
+	}
+	return routes, diags, diags.Err()
+}
+
+// parsePackages extracts the route docs of the files of pkgs selected by filter
+func parsePackages(fw FrameworkAdapter, filter *fileFilter, pkgs []*packages.Package, diags *Diagnostics) []RouteDoc {
+	// Collect router registrations first, since handlers may be registered in another package
+	registered := make(map[string][]Registration)
+	for _, reg := range fw.DetectRegistrations(adapterPackages(pkgs)) {
+		if key := handlerKey(reg.Handler); key != "" {
+			registered[key] = append(registered[key], reg)
 		}
+	}
 
-		for _, f := range node.Decls {
-			fn, ok := f.(*ast.FuncDecl)
-			if !ok || fn.Doc == nil {
+	var routes []RouteDoc
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			// Registrations in filtered files still count, but their functions are not documented
+			if !filter.matches(pkg.Fset.Position(file.Package).Filename) {
 				continue
 			}
+			routes = append(routes, parseFile(fw, pkg.Fset, file, pkg.TypesInfo, registered, diags)...)
+		}
+	}
+	return routes
+}
 
-			doc := RouteDoc{
-				Responses: make(map[string]Response),
-			}
+// handlerKey identifies a handler function by name rather than by object, so
+// that registrations match handlers of packages loaded separately by a Project
+func handlerKey(obj types.Object) string {
+	if fn, ok := obj.(*types.Func); ok {
+		return fn.FullName()
+	}
+	return ""
+}
 
-			for _, comment := range fn.Doc.List {
-				text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+// runtimeFuncName returns the name runtime.FuncForPC reports for the function
+// or method obj: its import path, with the dots of the last element escaped,
+// or main in package main, followed by the receiver type and the name
+func runtimeFuncName(obj types.Object) string {
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
 
-				switch {
-				case strings.HasPrefix(text, "@Summary "):
-					doc.Summary = strings.TrimPrefix(text, "@Summary ")
-				case strings.HasPrefix(text, "@Description "):
-					doc.Description = strings.TrimPrefix(text, "@Description ")
-				case strings.HasPrefix(text, "@Tags "):
-					doc.Tags = strings.Split(strings.TrimPrefix(text, "@Tags "), ",")
-				case strings.HasPrefix(text, "@Success "):
-					// Format: @Success 200 {object} ModelName "Description" OR @Success 200 "Description"
-					parts := strings.Fields(text[len("@Success "):])
-					if len(parts) >= 2 {
-						resp := Response{
-							StatusCode: parts[0],
-							MediaType:  "application/json",
-						}
-
-						// Check if it has a model specification
-						if len(parts) >= 3 && strings.HasPrefix(parts[1], "{") {
-							// Format: @Success 200 {object} ModelName "Description"
-							resp.Model = parts[2]
-							if len(parts) > 3 {
-								resp.Description = strings.Join(parts[3:], " ")
-								resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
-							}
-						} else {
-							// Format: @Success 200 "Description" (no model)
-							resp.Model = "" // No model
-							if len(parts) > 1 {
-								resp.Description = strings.Join(parts[1:], " ")
-								resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
-							}
-						}
-						doc.Responses[parts[0]] = resp
-					}
-				case strings.HasPrefix(text, "@Failure "):
-					// Format: @Failure 400 {object} ErrorModel "Description" OR @Failure 400 "Description"
-					parts := strings.Fields(text[len("@Failure "):])
-					if len(parts) >= 2 {
-						resp := Response{
-							StatusCode: parts[0],
-							MediaType:  "application/json",
-						}
-
-						// Check if it has a model specification
-						if len(parts) >= 3 && strings.HasPrefix(parts[1], "{") {
-							// Format: @Failure 400 {object} ModelName "Description"
-							resp.Model = parts[2]
-							if len(parts) > 3 {
-								resp.Description = strings.Join(parts[3:], " ")
-								resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
-							}
-						} else {
-							// Format: @Failure 400 "Description" (no model)
-							resp.Model = "" // No model
-							if len(parts) > 1 {
-								resp.Description = strings.Join(parts[1:], " ")
-								resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
-							}
-						}
-						doc.Responses[parts[0]] = resp
-					}
-				case strings.HasPrefix(text, "@Router "):
-					parts := strings.Fields(strings.TrimPrefix(text, "@Router "))
-					if len(parts) == 2 {
-						doc.Path = normalizePath(parts[0])
-						doc.Method = strings.Trim(parts[1], "[]")
-					}
-				case strings.HasPrefix(text, "@Param "):
-					// Format: @Param name in type required "description"
-					// Example: @Param X-Correlation-ID header string true "Tracking ID"
-					parts := strings.Fields(text[len("@Param "):])
-					if len(parts) >= 4 {
-						param := Parameter{
-							Name:     parts[0],
-							In:       parts[1],
-							Schema:   parts[2],
-							Required: parts[3] == "true",
-						}
-						if len(parts) > 4 {
-							param.Description = strings.Join(parts[4:], " ")
-							param.Description = strings.Trim(param.Description, `"`)
-						}
-						doc.Params = append(doc.Params, param)
-					}
-				case strings.HasPrefix(text, "@RequestBody "):
-					// Format: @RequestBody {object} ModelName true "Description"
-					parts := strings.Fields(text[len("@RequestBody "):])
-					if len(parts) >= 4 {
-						doc.RequestBody = &RequestBody{
-							Model:       parts[1],                     // e.g., MyStruct
-							Required:    parts[2] == "true",           // true or false
-							Description: strings.Join(parts[3:], " "), // "User payload"
-							MediaType:   "application/json",           // default for now
-						}
+	pkgPath := fn.Pkg().Path()
+	if fn.Pkg().Name() == "main" {
+		pkgPath = "main"
+	} else if i := strings.LastIndex(pkgPath, "/"); strings.Contains(pkgPath[i+1:], ".") {
+		pkgPath = pkgPath[:i+1] + strings.ReplaceAll(pkgPath[i+1:], ".", "%2e")
+	}
+
+	name := fn.Name()
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		recvType := recv.Type()
+		ptr, isPointer := recvType.(*types.Pointer)
+		if isPointer {
+			recvType = ptr.Elem()
+		}
+		recvName := types.TypeString(recvType, func(*types.Package) string { return "" })
+		if i := strings.Index(recvName, "["); i >= 0 {
+			recvName = recvName[:i] + "[...]"
+		}
+		if isPointer {
+			recvName = "(*" + recvName + ")"
+		}
+		name = recvName + "." + name
+	}
+	return pkgPath + "." + name
+}
+
+// parseFile extracts route docs from the annotated or registered functions of a single file
+func parseFile(fw FrameworkAdapter, fset *token.FileSet, node *ast.File, info *types.Info, registered map[string][]Registration, diags *Diagnostics) []RouteDoc {
+	var routes []RouteDoc
+
+	for _, f := range node.Decls {
+		fn, ok := f.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		registrations := registered[handlerKey(info.Defs[fn.Name])]
+		if fn.Doc == nil && len(registrations) == 0 {
+			continue
+		}
+
+		doc := RouteDoc{
+			Responses: make(map[string]Response),
+			Handler:   fn.Name.Name,
+			Func:      runtimeFuncName(info.Defs[fn.Name]),
+			Position:  fset.Position(fn.Pos()).String(),
+		}
+
+		var comments []*ast.Comment
+		if fn.Doc != nil {
+			comments = fn.Doc.List
+		}
+
+		for _, comment := range comments {
+			applyAnnotation(&doc, strings.TrimSpace(strings.TrimPrefix(comment.Text, "//")))
+		}
+
+		// Only handler bodies are auto-detected
+		isHandler := fw.IsHandler(fn, info)
+
+		if len(doc.Headers) == 0 && isHandler {
+			doc.Headers = append(doc.Headers, fw.DetectHeaders(fn, info)...)
+		}
+
+		if len(doc.Params) == 0 && isHandler {
+			doc.Params = append(doc.Params, fw.DetectParameters(fn, info)...)
+		}
+
+		// @Webhook and @Router win; otherwise the handler is documented under the routes it is registered with
+		if doc.Webhook != "" {
+			// Webhooks are requests the API sends, not routes it serves
+			registrations = []Registration{{Method: doc.Method}}
+		} else if len(doc.Routers) > 0 {
+			documented := make([]Registration, 0, len(doc.Routers))
+			for _, router := range doc.Routers {
+				warnRouterMismatch(fw, doc, router, registrations, diags)
+				documented = append(documented, Registration{
+					Method:      router.Method,
+					Path:        router.Path,
+					Annotations: matchingAnnotations(fw, router, registrations),
+				})
+			}
+			registrations = documented
+		}
+
+		if len(registrations) > 0 {
+			// Inject inferred request body if missing and the handler binds one
+			if doc.RequestBody == nil && isHandler {
+				if models := fw.DetectRequestBody(fn, info); len(models) > 0 {
+					doc.RequestBody = &RequestBody{
+						Model:       models[0],
+						Required:    true,
+						Description: "Auto-detected request body",
+						MediaType:   "application/json",
 					}
-				case strings.HasPrefix(text, "@Header "):
-					// Format: @Header 200 X-Header string true "Description"
-					parts := strings.Fields(text[len("@Header "):])
-					if len(parts) >= 5 {
-						doc.Headers = append(doc.Headers, Header{
-							StatusCode:  parts[0],
-							Name:        parts[1],
-							Type:        parts[2],
-							Required:    parts[3] == "true",
-							Description: strings.Join(parts[4:], " "),
-						})
+				}
+			}
+
+			// Inject inferred response models if none are defined via annotations
+			if len(doc.Responses) == 0 && isHandler {
+				inferred := fw.DetectResponses(fn, info)
+				for status, model := range inferred {
+					doc.Responses[status] = Response{
+						StatusCode:  status,
+						MediaType:   "application/json",
+						Model:       model,
+						Description: "Auto-detected response model",
 					}
-				case strings.HasPrefix(text, "@Security "):
-					securityText := strings.TrimSpace(strings.TrimPrefix(text, "@Security "))
-					securityScheme := parseSecurityScheme(securityText)
-					doc.SecuritySchemes = append(doc.SecuritySchemes, securityScheme)
-				case strings.EqualFold(text, "@Deprecated"):
-					doc.Deprecated = true
+				}
+			}
+			if isHandler {
+				doc.SkippedCalls = fw.DetectSkippedCalls(fn, info, fset)
+				for _, call := range doc.SkippedCalls {
+					diags.Warnf(CodeSkippedCall, call.Position, "", "skipped %s on %s: receiver is not a %s context", call.Method, call.Receiver, fw.Name())
 				}
 			}
 
-			if len(doc.Headers) == 0 {
-				headers, err := DetectHeaders(fn)
-				if err == nil && len(headers) > 0 {
-					doc.Headers = append(doc.Headers, headers...)
+			for i, reg := range registrations {
+				route := routeFor(fw, doc, reg)
+				if doc.Webhook == "" && i < len(doc.Routers) {
+					// Per-route overrides of the @Router line
+					route.Deprecated = route.Deprecated || doc.Routers[i].Deprecated
+					if doc.Routers[i].OperationID != "" {
+						route.OperationID = doc.Routers[i].OperationID
+					}
 				}
+				routes = append(routes, route)
+			}
+		}
+	}
+
+	return routes
+}
+
+// warnRouterMismatch reports a warning when a handler's @Router matches none of
+// the routes it is registered with
+func warnRouterMismatch(fw FrameworkAdapter, doc RouteDoc, router Router, registrations []Registration, diags *Diagnostics) {
+	if len(registrations) == 0 {
+		return
+	}
+
+	routerPath, _ := fw.NormalizePath(router.Path)
+	var registered []string
+	for _, reg := range registrations {
+		if registrationMatches(fw, router, reg) {
+			return
+		}
+		regPath, _ := fw.NormalizePath(reg.Path)
+		registered = append(registered, strings.ToUpper(reg.Method)+" "+regPath)
+	}
+
+	diags.Warnf(CodeRouterMismatch, doc.Position, "", "@Router %s %s of %s does not match its registration (%s)",
+		strings.ToUpper(router.Method), routerPath, doc.Handler, strings.Join(registered, ", "))
+}
+
+// registrationMatches reports whether reg registers the route of router
+func registrationMatches(fw FrameworkAdapter, router Router, reg Registration) bool {
+	routerPath, _ := fw.NormalizePath(router.Path)
+	regPath, _ := fw.NormalizePath(reg.Path)
+	return regPath == routerPath && (reg.Method == "any" || strings.EqualFold(reg.Method, router.Method))
+}
+
+// matchingAnnotations returns the group annotations of the registrations matching router
+func matchingAnnotations(fw FrameworkAdapter, router Router, registrations []Registration) []string {
+	var annotations []string
+	for _, reg := range registrations {
+		if registrationMatches(fw, router, reg) {
+			annotations = appendAnnotations(annotations, reg.Annotations)
+		}
+	}
+	return annotations
+}
+
+// annotationArity is the number of fields an annotation needs after its name
+// to be read by applyAnnotation, which ignores it otherwise; max 0 is unbounded.
+// Lint reports the annotations outside of it.
+var annotationArity = map[string]struct{ min, max int }{
+	"@Param":       {min: 4}, // name in type required ["description"]
+	"@RequestBody": {min: 4}, // {object} ModelName required "description"
+	"@Success":     {min: 2}, // code [{object} ModelName] "description"
+	"@Failure":     {min: 2},
+	"@Header":      {min: 5}, // code name type required "description"
+	"@Router":      {min: 2}, // /path [method] [options]
+	"@Webhook":     {min: 2, max: 2},
+}
+
+// hasArity reports whether an annotation with n fields after its name is read
+func hasArity(name string, n int) bool {
+	arity := annotationArity[name]
+	return n >= arity.min && (arity.max == 0 || n <= arity.max)
+}
+
+// field is a word of an annotation with its byte offset in the annotation text
+type field struct {
+	text   string
+	offset int
+}
+
+// annotationFields splits text into space separated words from byte offset
+// start on, keeping their offsets in text
+func annotationFields(text string, start int) []field {
+	var fields []field
+	begin := -1
+	for i, r := range text {
+		switch {
+		case i < start:
+		case unicode.IsSpace(r):
+			if begin >= 0 {
+				fields = append(fields, field{text[begin:i], begin})
+				begin = -1
 			}
+		case begin < 0:
+			begin = i
+		}
+	}
+	if begin >= 0 {
+		fields = append(fields, field{text[begin:], begin})
+	}
+	return fields
+}
 
-			if len(doc.Params) == 0 {
-				parameters, err := DetectParametersAndQuery(fn)
-				if err == nil && len(parameters) > 0 {
-					doc.Params = append(doc.Params, parameters...)
+// annotationArgs returns the words of an annotation line after its name, if
+// it has the arity of the annotation
+func annotationArgs(text string) ([]string, bool) {
+	fields := annotationFields(text, 0)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	var args []string
+	for _, f := range fields[1:] {
+		args = append(args, f.text)
+	}
+	return args, hasArity(fields[0].text, len(args))
+}
+
+// applyAnnotation applies a single annotation line such as "@Summary Get user" to doc
+func applyAnnotation(doc *RouteDoc, text string) {
+	switch {
+	case strings.HasPrefix(text, "@Summary "):
+		doc.Summary = strings.TrimPrefix(text, "@Summary ")
+	case strings.HasPrefix(text, "@Description "):
+		doc.Description = strings.TrimPrefix(text, "@Description ")
+	case strings.HasPrefix(text, "@Tags "):
+		doc.Tags = strings.Split(strings.TrimPrefix(text, "@Tags "), ",")
+	case strings.HasPrefix(text, "@Success "):
+		// Format: @Success 200 {object} ModelName "Description" OR @Success 200 "Description"
+		if parts, ok := annotationArgs(text); ok {
+			resp := Response{
+				StatusCode: parts[0],
+				MediaType:  "application/json",
+			}
+
+			// Check if it has a model specification
+			if len(parts) >= 3 && strings.HasPrefix(parts[1], "{") {
+				// Format: @Success 200 {object} ModelName "Description"
+				resp.Model = parts[2]
+				if len(parts) > 3 {
+					resp.Description = strings.Join(parts[3:], " ")
+					resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
+				}
+			} else {
+				// Format: @Success 200 "Description" (no model)
+				resp.Model = "" // No model
+				if len(parts) > 1 {
+					resp.Description = strings.Join(parts[1:], " ")
+					resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
 				}
 			}
+			doc.Responses[parts[0]] = resp
+		}
+	case strings.HasPrefix(text, "@Failure "):
+		// Format: @Failure 400 {object} ErrorModel "Description" OR @Failure 400 "Description"
+		if parts, ok := annotationArgs(text); ok {
+			resp := Response{
+				StatusCode: parts[0],
+				MediaType:  "application/json",
+			}
 
-			if doc.Path != "" && doc.Method != "" {
-				// Inject inferred request body if missing and ShouldBindJSON is used
-				if doc.RequestBody == nil {
-					modelMap, err := DetectRequestBodyType(fn)
-					if err == nil && len(modelMap) > 0 {
-						for structName := range modelMap {
-							doc.RequestBody = &RequestBody{
-								Model:       structName,
-								Required:    true,
-								Description: "Auto-detected request body",
-								MediaType:   "application/json",
-							}
-							break
-						}
-					}
+			// Check if it has a model specification
+			if len(parts) >= 3 && strings.HasPrefix(parts[1], "{") {
+				// Format: @Failure 400 {object} ModelName "Description"
+				resp.Model = parts[2]
+				if len(parts) > 3 {
+					resp.Description = strings.Join(parts[3:], " ")
+					resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
+				}
+			} else {
+				// Format: @Failure 400 "Description" (no model)
+				resp.Model = "" // No model
+				if len(parts) > 1 {
+					resp.Description = strings.Join(parts[1:], " ")
+					resp.Description = strings.Trim(resp.Description, `"`) // remove quotes
 				}
+			}
+			doc.Responses[parts[0]] = resp
+		}
+	case strings.HasPrefix(text, "@Router "):
+		// Format: @Router /path [method] [deprecated] [operationId=name]
+		if parts, ok := annotationArgs(text); ok {
+			router := Router{
+				Path:   parts[0],
+				Method: strings.ToLower(strings.Trim(parts[1], "[]")),
+			}
+			for _, override := range parts[2:] {
+				switch {
+				case strings.EqualFold(override, "deprecated"):
+					router.Deprecated = true
+				case strings.HasPrefix(override, "operationId="):
+					router.OperationID = strings.TrimPrefix(override, "operationId=")
+				}
+			}
 
-				// Inject inferred response models if none are defined via annotations
-				if len(doc.Responses) == 0 {
-					inferred := DetectResponseModel(fn)
-					for status, model := range inferred {
-						doc.Responses[status] = Response{
-							StatusCode:  status,
-							MediaType:   "application/json",
-							Model:       model,
-							Description: "Auto-detected response model",
-						}
-					}
+			if len(doc.Routers) == 0 {
+				doc.Path = router.Path
+				doc.Method = router.Method
+			}
+			doc.Routers = append(doc.Routers, router)
+		}
+	case strings.HasPrefix(text, "@Webhook "):
+		// Format: @Webhook name [method]
+		if parts, ok := annotationArgs(text); ok {
+			doc.Webhook = parts[0]
+			doc.Method = strings.ToLower(strings.Trim(parts[1], "[]"))
+		}
+	case strings.HasPrefix(text, "@Param "):
+		// Format: @Param name in type required "description"
+		// Example: @Param X-Correlation-ID header string true "Tracking ID"
+		if parts, ok := annotationArgs(text); ok {
+			param := Parameter{
+				Name:     parts[0],
+				In:       parts[1],
+				Schema:   parts[2],
+				Required: parts[3] == "true",
+			}
+			if len(parts) > 4 {
+				param.Description = strings.Join(parts[4:], " ")
+				param.Description = strings.Trim(param.Description, `"`)
+			}
+			doc.Params = append(doc.Params, param)
+		}
+	case strings.HasPrefix(text, "@RequestBody "):
+		// Format: @RequestBody {object} ModelName true "Description"
+		if parts, ok := annotationArgs(text); ok {
+			doc.RequestBody = &RequestBody{
+				Model:       parts[1],                     // e.g., MyStruct
+				Required:    parts[2] == "true",           // true or false
+				Description: strings.Join(parts[3:], " "), // "User payload"
+				MediaType:   "application/json",           // default for now
+			}
+		}
+	case strings.HasPrefix(text, "@Header "):
+		// Format: @Header 200 X-Header string true "Description"
+		if parts, ok := annotationArgs(text); ok {
+			doc.Headers = append(doc.Headers, Header{
+				StatusCode:  parts[0],
+				Name:        parts[1],
+				Type:        parts[2],
+				Required:    parts[3] == "true",
+				Description: strings.Join(parts[4:], " "),
+			})
+		}
+	case strings.HasPrefix(text, "@Security "):
+		securityText := strings.TrimSpace(strings.TrimPrefix(text, "@Security "))
+		securityScheme := parseSecurityScheme(securityText)
+		doc.SecuritySchemes = append(doc.SecuritySchemes, securityScheme)
+	case strings.EqualFold(text, "@Deprecated"):
+		doc.Deprecated = true
+	}
+}
+
+// routeFor returns a copy of doc documented under the registration's method and
+// framework route path, with the registration's group annotations applied
+func routeFor(fw FrameworkAdapter, doc RouteDoc, reg Registration) RouteDoc {
+	var patterns map[string]string
+
+	route := doc
+	route.Method = reg.Method
+	route.Path, patterns = fw.NormalizePath(reg.Path)
+	route.Params = append([]Parameter(nil), doc.Params...)
+	applyGroupAnnotations(&route, reg.Annotations)
+	applyPathPatterns(route.Params, patterns)
+
+	return route
+}
+
+// groupAnnotations are the annotations a router group passes on to its routes
+var groupAnnotations = []string{"@Tags ", "@Security ", "@Failure ", "@Param ", "@Header ", "@Deprecated"}
+
+// applyGroupAnnotations merges group level annotations into route. Tags are
+// added to the route's own, while security applies only to routes without any
+// and responses, parameters and headers only where the route has none of its own.
+func applyGroupAnnotations(route *RouteDoc, annotations []string) {
+	group := RouteDoc{Responses: make(map[string]Response)}
+	for _, annotation := range annotations {
+		for _, prefix := range groupAnnotations {
+			if strings.HasPrefix(annotation, prefix) || strings.EqualFold(annotation, prefix) {
+				// Nested groups each add their own tags
+				tags := group.Tags
+				applyAnnotation(&group, annotation)
+				if prefix == "@Tags " {
+					group.Tags = append(tags, group.Tags...)
 				}
-				routes = append(routes, doc)
+				break
 			}
 		}
+	}
 
-		return nil
-	})
+	for _, tag := range group.Tags {
+		if !slices.Contains(route.Tags, tag) {
+			route.Tags = append(slices.Clip(route.Tags), tag)
+		}
+	}
 
-	return routes, err
+	if len(route.SecuritySchemes) == 0 {
+		route.SecuritySchemes = group.SecuritySchemes
+	}
+
+	if len(group.Responses) > 0 {
+		// The responses map is shared between the routes of a handler
+		responses := make(map[string]Response, len(route.Responses)+len(group.Responses))
+		maps.Copy(responses, group.Responses)
+		maps.Copy(responses, route.Responses)
+		route.Responses = responses
+	}
+
+	for _, param := range group.Params {
+		if !slices.ContainsFunc(route.Params, func(p Parameter) bool { return p.In == param.In && p.Name == param.Name }) {
+			route.Params = append(route.Params, param)
+		}
+	}
+
+	for _, header := range group.Headers {
+		if !slices.ContainsFunc(route.Headers, func(h Header) bool { return h.StatusCode == header.StatusCode && h.Name == header.Name }) {
+			route.Headers = append(slices.Clip(route.Headers), header)
+		}
+	}
+
+	route.Deprecated = route.Deprecated || group.Deprecated
 }
 
 func normalizePath(path string) string {
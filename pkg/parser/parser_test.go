@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRuntimeFuncName(t *testing.T) {
+	const src = `package handlers
+
+type Server struct{}
+
+func (s *Server) GetUser() {}
+func (s Server) ListUsers() {}
+
+type Page[T any] struct{}
+
+func (p *Page[T]) Next() {}
+
+func Health() {}
+`
+	_, file, info := typeCheck(t, src, nil)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Health", "example.com/handlers.Health"},
+		{"GetUser", "example.com/handlers.(*Server).GetUser"},
+		{"ListUsers", "example.com/handlers.Server.ListUsers"},
+		{"Next", "example.com/handlers.(*Page[...]).Next"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := funcDecl(t, file, tt.name)
+			if got := runtimeFuncName(info.Defs[fn.Name]); got != tt.want {
+				t.Errorf("runtimeFuncName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultipleRouters(t *testing.T) {
+	const src = `package handlers
+
+import "github.com/gin-gonic/gin"
+
+// @Summary Get user by ID
+// @Tags users
+// @Router /users/{id} [get]
+// @Router /users/{id} [head] operationId=headUser
+// @Router /user/{id} [get] deprecated
+func GetUser(c *gin.Context) {}
+
+// @Summary Legacy lookup
+// @Deprecated
+// @Router /lookup/{id} [get] operationId=lookupUser
+// @Router /find/{id} [get]
+func Lookup(c *gin.Context) {}
+`
+	fset, file, info := typeCheck(t, src, map[string]string{ginPkgPath: ginStub})
+	var diags Diagnostics
+	routes := parseFile(ginAdapter{}, fset, file, info, nil, &diags)
+
+	var got []string
+	for _, route := range routes {
+		s := route.Method + " " + route.Path + " " + route.Handler
+		if route.OperationID != "" {
+			s += " operationId=" + route.OperationID
+		}
+		if route.Deprecated {
+			s += " deprecated"
+		}
+		if route.Summary == "" {
+			t.Errorf("%s lost the handler's annotations: %+v", s, route)
+		}
+		got = append(got, s)
+	}
+	want := []string{
+		"get /users/{id} GetUser",
+		"head /users/{id} GetUser operationId=headUser",
+		"get /user/{id} GetUser deprecated",
+		"get /lookup/{id} Lookup operationId=lookupUser deprecated",
+		"get /find/{id} Lookup deprecated",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("routes = %q, want %q", got, want)
+	}
+	if len(diags) > 0 {
+		t.Errorf("diagnostics = %v, want none", diags)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go": `package main
+
+import "net/http"
+
+// @Summary List users
+// @Router /people [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", ListUsers)
+	http.ListenAndServe(":8080", mux)
+}
+`,
+	})
+
+	for _, strict := range []bool{false, true} {
+		routes, diags, err := ParseDirectoryWithOptions(dir, Options{Framework: "http", Strict: strict})
+		if len(routes) != 1 {
+			t.Errorf("strict %v: routes = %+v, want the documented one", strict, routes)
+		}
+		if len(diags) != 1 || diags[0].Code != CodeRouterMismatch {
+			t.Fatalf("strict %v: diagnostics = %v, want a %s", strict, diags, CodeRouterMismatch)
+		}
+
+		want := SeverityWarning
+		if strict {
+			want = SeverityError
+		}
+		if diags[0].Severity != want {
+			t.Errorf("strict %v: severity = %s, want %s", strict, diags[0].Severity, want)
+		}
+		if (err != nil) != strict {
+			t.Errorf("strict %v: err = %v", strict, err)
+		}
+	}
+}
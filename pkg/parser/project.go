@@ -0,0 +1,305 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Project keeps the packages below a directory loaded between parses, so that
+// after source changes only the affected packages are loaded again, as in
+// watch mode. A Project is not safe for concurrent use.
+type Project struct {
+	dir    string
+	roots  []string // absolute directories below which packages are loaded
+	opts   Options
+	fw     FrameworkAdapter
+	filter *fileFilter
+	pkgs   map[string]*packages.Package // loaded packages by package path
+}
+
+// NewProject returns a project for the packages below dir selected by opts,
+// as ParseDirectoryWithOptions parses them. Call Load before Parse.
+func NewProject(dir string, opts Options) (*Project, error) {
+	fw := opts.Adapter
+	if fw == nil {
+		var err error
+		if fw, err = LookupFramework(opts.Framework); err != nil {
+			return nil, err
+		}
+	}
+
+	filter, err := newFileFilter(dir, opts.Include, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	roots := []string{abs}
+	if len(opts.Roots) > 0 {
+		roots = nil
+		for _, root := range opts.Roots {
+			if !filepath.IsAbs(root) {
+				root = filepath.Join(abs, root)
+			}
+			roots = append(roots, filepath.Clean(root))
+		}
+	}
+
+	return &Project{dir: abs, roots: roots, opts: opts, fw: fw, filter: filter}, nil
+}
+
+// Load loads every package below the roots, replacing the loaded packages
+func (p *Project) Load() error {
+	pkgs, _, err := loadPackages(p.dir, p.opts.Roots)
+	if err != nil {
+		return err
+	}
+
+	p.pkgs = make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		p.pkgs[pkg.PkgPath] = pkg
+	}
+	return nil
+}
+
+// Reload loads again the packages affected by changes to paths, which are
+// files or directories that were written, created or removed: the packages
+// in those directories and the packages importing them, directly or not.
+// Changes to go.mod, go.sum or go.work reload every package. Reload returns
+// the paths of the packages loaded again or removed, if any.
+func (p *Project) Reload(paths []string) ([]string, error) {
+	graph := p.graph()
+
+	affected := make(map[string]bool) // package paths
+	created := make(map[string]bool)  // directories of packages that were not loaded
+	for _, path := range paths {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch filepath.Base(path) {
+		case "go.mod", "go.sum", "go.work":
+			if err := p.Load(); err != nil {
+				return nil, err
+			}
+			return slices.Sorted(maps.Keys(p.pkgs)), nil
+		}
+
+		var dir string
+		if _, ok := graph.byDir[path]; ok || isDir(path) {
+			dir = path
+		} else if filepath.Ext(path) == ".go" && !strings.HasSuffix(path, "_test.go") {
+			dir = filepath.Dir(path)
+		} else {
+			continue
+		}
+
+		if pkgPath, ok := graph.byDir[dir]; ok {
+			affected[pkgPath] = true
+		} else if p.inRoots(dir) && isDir(dir) {
+			created[dir] = true
+		}
+	}
+
+	// Importers are type-checked against the packages they import, so they are reloaded too
+	queue := slices.Collect(maps.Keys(affected))
+	for len(queue) > 0 {
+		pkgPath := queue[0]
+		queue = queue[1:]
+		for _, importer := range graph.importers[pkgPath] {
+			if !affected[importer] {
+				affected[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+
+	// Packages imported from outside the roots are loaded along with their importers
+	var patterns []string
+	reloaded := make(map[string]bool)
+	for pkgPath := range affected {
+		if pkg, ok := p.pkgs[pkgPath]; ok {
+			delete(p.pkgs, pkgPath)
+			reloaded[pkgPath] = true
+			if pkg.Dir != "" {
+				patterns = append(patterns, pkg.Dir)
+			} else {
+				patterns = append(patterns, pkgPath)
+			}
+		}
+	}
+	patterns = append(patterns, slices.Collect(maps.Keys(created))...)
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	sort.Strings(patterns)
+
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  p.dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages in %s: %w", p.dir, err)
+	}
+
+	for _, pkg := range pkgs {
+		// The directory of a removed package no longer has Go files
+		if len(pkg.GoFiles) == 0 && len(pkg.CompiledGoFiles) == 0 {
+			continue
+		}
+		p.pkgs[pkg.PkgPath] = pkg
+		reloaded[pkg.PkgPath] = true
+	}
+	return slices.Sorted(maps.Keys(reloaded)), nil
+}
+
+// Parse extracts the route docs of the loaded packages, as ParseDirectoryWithOptions
+func (p *Project) Parse() ([]RouteDoc, Diagnostics, error) {
+	pkgs := p.packages()
+	diags := packageDiagnostics(pkgs)
+	routes := parsePackages(p.fw, p.filter, pkgs, &diags)
+	if p.opts.Strict {
+		diags.Strict()
+	}
+	return routes, diags, diags.Err()
+}
+
+// Models returns the named types declared in the loaded packages and in the
+// packages they import from the project directory, as DiscoverModelsWithOptions
+func (p *Project) Models() map[string]types.Type {
+	graph := p.graph()
+	pkgs := slices.Collect(maps.Values(graph.byPath))
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].PkgPath < pkgs[j].PkgPath
+	})
+	return packageModels(pkgs, p.filter)
+}
+
+// WatchDirs returns the directories to watch for changes: the directories
+// below the roots, where packages may be added, and the directories of the
+// packages imported from the project directory.
+func (p *Project) WatchDirs() ([]string, error) {
+	dirs := make(map[string]bool)
+	for _, root := range p.roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			if path != root && skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			dirs[path] = true
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	for dir := range p.graph().byDir {
+		dirs[dir] = true
+	}
+	return slices.Sorted(maps.Keys(dirs)), nil
+}
+
+// packages returns the loaded packages in package path order
+func (p *Project) packages() []*packages.Package {
+	pkgs := make([]*packages.Package, 0, len(p.pkgs))
+	for _, pkgPath := range slices.Sorted(maps.Keys(p.pkgs)) {
+		pkgs = append(pkgs, p.pkgs[pkgPath])
+	}
+	return pkgs
+}
+
+// projectGraph is the import graph of the loaded packages and the packages
+// they import from the project directory
+type projectGraph struct {
+	byPath    map[string]*packages.Package
+	byDir     map[string]string   // package path by directory
+	importers map[string][]string // package paths of the importers by package path
+}
+
+func (p *Project) graph() projectGraph {
+	graph := projectGraph{
+		byPath:    make(map[string]*packages.Package),
+		byDir:     make(map[string]string),
+		importers: make(map[string][]string),
+	}
+
+	// Loaded packages win over the older copies imported by packages loaded before them
+	pkgs := p.packages()
+	for _, pkg := range pkgs {
+		graph.byPath[pkg.PkgPath] = pkg
+	}
+	packages.Visit(pkgs, func(pkg *packages.Package) bool {
+		if _, ok := p.pkgs[pkg.PkgPath]; !ok && !within(pkg.Dir, p.dir) {
+			return false
+		}
+		if _, ok := graph.byPath[pkg.PkgPath]; !ok {
+			graph.byPath[pkg.PkgPath] = pkg
+		}
+		return true
+	}, nil)
+
+	for pkgPath, pkg := range graph.byPath {
+		if pkg.Dir != "" {
+			graph.byDir[pkg.Dir] = pkgPath
+		}
+		for _, imp := range pkg.Imports {
+			if _, ok := graph.byPath[imp.PkgPath]; ok && !slices.Contains(graph.importers[imp.PkgPath], pkgPath) {
+				graph.importers[imp.PkgPath] = append(graph.importers[imp.PkgPath], pkgPath)
+			}
+		}
+	}
+	return graph
+}
+
+// inRoots reports whether dir may hold a package matched by the patterns of the roots
+func (p *Project) inRoots(dir string) bool {
+	for _, root := range p.roots {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if rel == "." || !slices.ContainsFunc(strings.Split(rel, string(filepath.Separator)), skipDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipDir reports whether the ./... pattern skips directories named name
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata"
+}
+
+// within reports whether path is dir or below it
+func within(path, dir string) bool {
+	if path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// projectFiles is a module whose users and orders packages are registered by main
+var projectFiles = map[string]string{
+	"main.go": `package main
+
+import (
+	"net/http"
+
+	"example.com/app/orders"
+	"example.com/app/users"
+)
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", users.GetUser)
+	mux.HandleFunc("GET /orders/{id}", orders.GetOrder)
+	http.ListenAndServe(":8080", mux)
+}
+`,
+	"users/users.go": `package users
+
+import "net/http"
+
+type User struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// @Summary Get a user
+// @Router /users/{id} [get]
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+`,
+	"orders/orders.go": `package orders
+
+import "net/http"
+
+type Order struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// @Summary Get an order
+// @Router /orders/{id} [get]
+func GetOrder(w http.ResponseWriter, r *http.Request) {}
+`,
+}
+
+func TestProjectReload(t *testing.T) {
+	dir := writeModule(t, projectFiles)
+	project, err := NewProject(dir, Options{Framework: "http"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := project.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := projectSummaries(t, project); got["/users/{id}"] != "Get a user" || got["/orders/{id}"] != "Get an order" {
+		t.Fatalf("summaries = %v", got)
+	}
+	order := project.Models()["example.com/app/orders.Order"]
+
+	writeFiles(t, dir, map[string]string{"users/users.go": `package users
+
+import "net/http"
+
+type User struct {
+	ID   string ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// @Summary Fetch a user
+// @Router /users/{id} [get]
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+`})
+
+	reloaded, err := project.Reload([]string{filepath.Join(dir, "users", "users.go")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// main imports users, so it is type-checked again; orders is left alone
+	if want := []string{"example.com/app", "example.com/app/users"}; !slices.Equal(reloaded, want) {
+		t.Errorf("Reload() = %v, want %v", reloaded, want)
+	}
+
+	if got := projectSummaries(t, project); got["/users/{id}"] != "Fetch a user" || got["/orders/{id}"] != "Get an order" {
+		t.Errorf("summaries after Reload = %v", got)
+	}
+	models := project.Models()
+	user, ok := models["example.com/app/users.User"].Underlying().(*types.Struct)
+	if !ok || user.NumFields() != 2 {
+		t.Errorf("User after Reload = %v, want the Name field", models["example.com/app/users.User"])
+	}
+	if models["example.com/app/orders.Order"] != order {
+		t.Errorf("Order was type-checked again")
+	}
+}
+
+func TestProjectNewPackage(t *testing.T) {
+	dir := writeModule(t, projectFiles)
+	project, err := NewProject(dir, Options{Framework: "http"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := project.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	products := filepath.Join(dir, "products")
+	dirs, err := project.WatchDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(dirs, products) || !slices.Contains(dirs, filepath.Join(dir, "users")) {
+		t.Fatalf("WatchDirs() = %v", dirs)
+	}
+
+	writeFiles(t, dir, map[string]string{"products/products.go": `package products
+
+import "net/http"
+
+// @Summary List products
+// @Router /products [get]
+func ListProducts(w http.ResponseWriter, r *http.Request) {}
+`})
+
+	dirs, err = project.WatchDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(dirs, products) {
+		t.Errorf("WatchDirs() = %v, want %s", dirs, products)
+	}
+
+	reloaded, err := project.Reload([]string{products})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"example.com/app/products"}; !slices.Equal(reloaded, want) {
+		t.Errorf("Reload() = %v, want %v", reloaded, want)
+	}
+	if got := projectSummaries(t, project); got["/products"] != "List products" {
+		t.Errorf("summaries after Reload = %v, want /products", got)
+	}
+
+	if err := os.RemoveAll(products); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := project.Reload([]string{products}); err != nil {
+		t.Fatal(err)
+	}
+	if got := projectSummaries(t, project); got["/products"] != "" {
+		t.Errorf("summaries after removing products = %v", got)
+	}
+}
+
+// projectSummaries parses the project and returns the route summaries by path
+func projectSummaries(t *testing.T, project *Project) map[string]string {
+	t.Helper()
+
+	routes, _, err := project.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	summaries := make(map[string]string)
+	for _, route := range routes {
+		summaries[route.Path] = route.Summary
+	}
+	return summaries
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// swaggerUIVersion is the version of the embedded Swagger UI, also used for the CDN
+const swaggerUIVersion = "5.18.2"
+
+//go:embed swagger-ui/swagger-ui.css swagger-ui/swagger-ui-bundle.js swagger-ui/favicon-32x32.png swagger-ui/favicon-16x16.png
+var swaggerUIFiles embed.FS
+
+// swaggerUIAssetsPath is the path of the embedded assets below the mount path.
+// It changes with the version, so the assets can be cached for good.
+const swaggerUIAssetsPath = "/swagger/assets/" + swaggerUIVersion + "/"
+
+// UIOptions configures the Swagger UI page
+type UIOptions struct {
+	// CDN loads the Swagger UI assets from unpkg.com, pinned to the embedded
+	// version, instead of serving the embedded copy. Browsers then need access
+	// to the internet.
+	CDN bool
+}
+
+// asset is an embedded Swagger UI file as served
+type asset struct {
+	body        []byte
+	contentType string
+	etag        string
+}
+
+// assetContentTypes are the content types of the embedded files, by extension,
+// independent of the MIME types configured on the host
+var assetContentTypes = map[string]string{
+	".css": "text/css; charset=utf-8",
+	".js":  "text/javascript; charset=utf-8",
+	".png": "image/png",
+}
+
+// swaggerUIAssets are the embedded Swagger UI files by name
+var swaggerUIAssets = loadSwaggerUIAssets()
+
+func loadSwaggerUIAssets() map[string]asset {
+	entries, err := swaggerUIFiles.ReadDir("swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+
+	assets := make(map[string]asset, len(entries))
+	for _, entry := range entries {
+		body, err := swaggerUIFiles.ReadFile("swagger-ui/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		sum := sha256.Sum256(body)
+		assets[entry.Name()] = asset{
+			body:        body,
+			contentType: assetContentTypes[path.Ext(entry.Name())],
+			etag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+		}
+	}
+	return assets
+}
+
+// SwaggerUIAssetsHandler serves the embedded Swagger UI files loaded by the
+// page of SwaggerUIHandler, by the last element of the request path.
+// Mount it below /swagger/assets/, e.g. mux.Handle("GET /swagger/assets/", ui.SwaggerUIAssetsHandler())
+func SwaggerUIAssetsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Assets of another version must not be cached under its URL
+		if !strings.HasSuffix(path.Dir(r.URL.Path), "/assets/"+swaggerUIVersion) {
+			http.NotFound(w, r)
+			return
+		}
+		serveAsset(w, r, path.Base(r.URL.Path))
+	})
+}
+
+// serveAsset serves the embedded file name. The URLs of the assets change
+// with the version, so they are cached for a year.
+func serveAsset(w http.ResponseWriter, r *http.Request, name string) {
+	a, ok := swaggerUIAssets[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", a.contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", a.etag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(a.body))
+}
+
+// swaggerUIAssetNames returns the names of the embedded files, to mount one route per file
+func swaggerUIAssetNames() []string {
+	return slices.Sorted(maps.Keys(swaggerUIAssets))
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"net/http"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterSwaggerUIEcho mounts GET /swagger on an echo server, and the
+// embedded Swagger UI files it loads below /swagger/assets/
+func RegisterSwaggerUIEcho(e *echo.Echo, path string) {
+	RegisterSwaggerUIEchoWithOptions(e, path, UIOptions{})
+}
+
+// RegisterSwaggerUIEchoWithOptions is RegisterSwaggerUIEcho configured by opts
+func RegisterSwaggerUIEchoWithOptions(e *echo.Echo, path string, opts UIOptions) {
+	page := swaggerUIHTML(path, opts)
+	e.GET("/swagger", func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "no-cache")
+		return c.HTML(http.StatusOK, page)
+	})
+
+	if opts.CDN {
+		return
+	}
+	for _, name := range swaggerUIAssetNames() {
+		e.GET(swaggerUIAssetsPath+name, func(c echo.Context) error {
+			serveAsset(c.Response(), c.Request(), name)
+			return nil
+		})
+	}
+}
+
+// RegisterSwaggerJSONHandlerEcho mounts GET /swagger/openapi.json on an echo server
+func RegisterSwaggerJSONHandlerEcho(e *echo.Echo, openapi *generator.OpenAPI) {
+	e.GET("/swagger/openapi.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, openapi)
+	})
+}
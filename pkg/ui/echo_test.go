@@ -0,0 +1,75 @@
+package ui_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+	"github.com/georgetjose/openapi3gen/pkg/ui"
+
+	"github.com/labstack/echo/v4"
+)
+
+// bundleSrc finds the URL of the Swagger UI bundle in the page
+var bundleSrc = regexp.MustCompile(`src="([^"]*swagger-ui-bundle\.js)"`)
+
+func echoGet(e *echo.Echo, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestRegisterSwaggerUIEcho(t *testing.T) {
+	e := echo.New()
+	ui.RegisterSwaggerUIEcho(e, "")
+
+	page := echoGet(e, "/swagger")
+	if page.Code != http.StatusOK || page.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("GET /swagger = %d with Cache-Control %q, want 200 and no-cache", page.Code, page.Header().Get("Cache-Control"))
+	}
+	if !strings.Contains(page.Body.String(), "url: '/swagger/openapi.json'") {
+		t.Errorf("page does not load /swagger/openapi.json:\n%s", page.Body)
+	}
+
+	match := bundleSrc.FindStringSubmatch(page.Body.String())
+	if match == nil || !strings.HasPrefix(match[1], "/swagger/assets/") {
+		t.Fatalf("page loads the bundle from %q, want the embedded assets", match)
+	}
+	bundle := echoGet(e, match[1])
+	if bundle.Code != http.StatusOK || !strings.Contains(bundle.Header().Get("Content-Type"), "javascript") {
+		t.Errorf("GET %s = %d with Content-Type %q, want the bundle", match[1], bundle.Code, bundle.Header().Get("Content-Type"))
+	}
+}
+
+func TestRegisterSwaggerUIEchoCDN(t *testing.T) {
+	e := echo.New()
+	ui.RegisterSwaggerUIEchoWithOptions(e, "", ui.UIOptions{CDN: true})
+
+	match := bundleSrc.FindStringSubmatch(echoGet(e, "/swagger").Body.String())
+	if match == nil || !strings.HasPrefix(match[1], "https://unpkg.com/swagger-ui-dist@") {
+		t.Fatalf("page loads the bundle from %q, want the CDN", match)
+	}
+	for _, route := range e.Routes() {
+		if strings.HasPrefix(route.Path, "/swagger/assets/") {
+			t.Errorf("asset route %s is mounted with the CDN", route.Path)
+		}
+	}
+}
+
+func TestRegisterSwaggerJSONHandlerEcho(t *testing.T) {
+	e := echo.New()
+	ui.RegisterSwaggerJSONHandlerEcho(e, liveSpec("Echo"))
+
+	rec := echoGet(e, "/swagger/openapi.json")
+	var spec generator.OpenAPI
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || spec.Info.Title != "Echo" {
+		t.Errorf("GET /swagger/openapi.json = %d with title %q, want 200 and the spec", rec.Code, spec.Info.Title)
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+)
+
+// SwaggerUIHandler serves the Swagger UI page as a plain http.Handler, for
+// http.ServeMux, chi or any other net/http compatible router.
+// Mount it at /swagger, e.g. mux.Handle("GET /swagger", ui.SwaggerUIHandler("")),
+// and SwaggerUIAssetsHandler for the files the page loads.
+func SwaggerUIHandler(path string) http.Handler {
+	return SwaggerUIHandlerWithOptions(path, UIOptions{})
+}
+
+// SwaggerUIHandlerWithOptions is SwaggerUIHandler configured by opts
+func SwaggerUIHandlerWithOptions(path string, opts UIOptions) http.Handler {
+	page := []byte(swaggerUIHTML(path, opts))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(page)
+	})
+}
+
+// SwaggerJSONHandler serves openapi as JSON as a plain http.Handler.
+// Mount it at /swagger/openapi.json
+func SwaggerJSONHandler(openapi *generator.OpenAPI) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := json.Marshal(openapi)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	})
+}
@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/labstack/echo/v4"
+)
+
+// SpecProvider provides the latest spec to the live spec handlers, which ask
+// for it on every request. Providers are created with NewSpecHolder, SpecFunc
+// and SpecFile, or implemented elsewhere, and must be safe for concurrent use.
+type SpecProvider interface {
+	// SpecDocument returns the spec to serve
+	SpecDocument() (*SpecDocument, error)
+}
+
+// SpecDocument is a spec as served: its JSON body and validators
+type SpecDocument struct {
+	Body     []byte    // JSON encoded spec
+	ETag     string    // Quoted entity tag of Body
+	Modified time.Time // Time the spec last changed, sent as Last-Modified unless zero
+}
+
+// NewSpecDocument encodes openapi as served, with an ETag derived from its content
+func NewSpecDocument(openapi *generator.OpenAPI, modified time.Time) (*SpecDocument, error) {
+	if openapi == nil {
+		return nil, errors.New("no spec to serve")
+	}
+	body, err := json.Marshal(openapi)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	return &SpecDocument{
+		Body:     body,
+		ETag:     `"` + hex.EncodeToString(sum[:16]) + `"`,
+		Modified: modified.UTC().Truncate(time.Second),
+	}, nil
+}
+
+// SpecHolder holds a spec that can be swapped with Store while requests are served
+type SpecHolder struct {
+	mu    sync.Mutex // serializes Store
+	state atomic.Pointer[heldSpec]
+}
+
+// heldSpec is the spec of a SpecHolder with its encoding, swapped as one
+type heldSpec struct {
+	api *generator.OpenAPI
+	doc *SpecDocument
+}
+
+// NewSpecHolder returns a holder serving openapi
+func NewSpecHolder(openapi *generator.OpenAPI) (*SpecHolder, error) {
+	h := &SpecHolder{}
+	if err := h.Store(openapi); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Store replaces the spec served, which must not be nil. The spec is encoded
+// once, here, so changes made to it afterwards are not served: store a new
+// spec instead. Storing an identical spec keeps its ETag and Last-Modified.
+func (h *SpecHolder) Store(openapi *generator.OpenAPI) error {
+	doc, err := NewSpecDocument(openapi, time.Now())
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if old := h.state.Load(); old != nil && old.doc.ETag == doc.ETag {
+		doc = old.doc
+	}
+	h.state.Store(&heldSpec{api: openapi, doc: doc})
+	return nil
+}
+
+// Load returns the spec served
+func (h *SpecHolder) Load() *generator.OpenAPI {
+	if state := h.state.Load(); state != nil {
+		return state.api
+	}
+	return nil
+}
+
+// SpecDocument returns the encoding of the spec served
+func (h *SpecHolder) SpecDocument() (*SpecDocument, error) {
+	state := h.state.Load()
+	if state == nil {
+		return nil, errors.New("no spec stored")
+	}
+	return state.doc, nil
+}
+
+// SpecFunc returns a provider serving the spec returned by fn, which is
+// called on every request. Last-Modified is the time the spec was first
+// served with its current content.
+func SpecFunc(fn func() (*generator.OpenAPI, error)) SpecProvider {
+	return &funcProvider{fn: fn}
+}
+
+type funcProvider struct {
+	fn func() (*generator.OpenAPI, error)
+
+	mu   sync.Mutex
+	last *SpecDocument
+}
+
+func (p *funcProvider) SpecDocument() (*SpecDocument, error) {
+	openapi, err := p.fn()
+	if err != nil {
+		return nil, err
+	}
+	doc, err := NewSpecDocument(openapi, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.last != nil && p.last.ETag == doc.ETag {
+		return p.last, nil
+	}
+	p.last = doc
+	return doc, nil
+}
+
+// SpecFile returns a provider serving the JSON or YAML spec at path, as
+// written by openapi3gen generate. The file is read again when its size or
+// modification time changes, and the last spec read is served while the file
+// cannot be read or parsed, for instance while it is being rewritten.
+func SpecFile(path string) SpecProvider {
+	return &fileProvider{path: path}
+}
+
+type fileProvider struct {
+	path string
+
+	mu      sync.Mutex
+	last    *SpecDocument
+	size    int64
+	modTime time.Time
+}
+
+func (p *fileProvider) SpecDocument() (*SpecDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return p.fallback(err)
+	}
+	if p.last != nil && info.Size() == p.size && info.ModTime().Equal(p.modTime) {
+		return p.last, nil
+	}
+
+	openapi, err := generator.ReadSpec(p.path)
+	if err != nil {
+		return p.fallback(err)
+	}
+	doc, err := NewSpecDocument(openapi, info.ModTime())
+	if err != nil {
+		return p.fallback(err)
+	}
+	p.last, p.size, p.modTime = doc, info.Size(), info.ModTime()
+	return doc, nil
+}
+
+// fallback returns the last spec read, or err if there is none
+func (p *fileProvider) fallback(err error) (*SpecDocument, error) {
+	if p.last != nil {
+		return p.last, nil
+	}
+	return nil, err
+}
+
+// SwaggerJSONProviderHandler serves the latest spec of provider as JSON as a
+// plain http.Handler, with an ETag and Last-Modified so that clients can
+// poll it with conditional requests. Mount it at /swagger/openapi.json
+func SwaggerJSONProviderHandler(provider SpecProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, err := provider.SpecDocument()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		if doc.ETag != "" {
+			w.Header().Set("ETag", doc.ETag)
+		}
+		// ServeContent answers If-None-Match and If-Modified-Since with 304 Not Modified
+		http.ServeContent(w, r, "openapi.json", doc.Modified, bytes.NewReader(doc.Body))
+	})
+}
+
+// RegisterSwaggerJSONProvider mounts GET /swagger/openapi.json serving the latest spec of provider
+func RegisterSwaggerJSONProvider(r *gin.Engine, provider SpecProvider) {
+	handler := SwaggerJSONProviderHandler(provider)
+	// A closure rather than gin.WrapH, so that ReconcileRoutes recognises the route by its handler name
+	r.GET("/swagger/openapi.json", func(c *gin.Context) {
+		handler.ServeHTTP(c.Writer, c.Request)
+	})
+}
+
+// RegisterSwaggerJSONProviderEcho mounts GET /swagger/openapi.json on an echo
+// server, serving the latest spec of provider
+func RegisterSwaggerJSONProviderEcho(e *echo.Echo, provider SpecProvider) {
+	e.GET("/swagger/openapi.json", echo.WrapHandler(SwaggerJSONProviderHandler(provider)))
+}
@@ -0,0 +1,96 @@
+package ui_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+	"github.com/georgetjose/openapi3gen/pkg/ui"
+)
+
+func liveSpec(title string) *generator.OpenAPI {
+	return &generator.OpenAPI{OpenAPI: generator.OpenAPIVersion30, Info: generator.Info{Title: title, Version: "1.0.0"}}
+}
+
+func TestSpecHolder(t *testing.T) {
+	if _, err := ui.NewSpecHolder(nil); err == nil {
+		t.Error("NewSpecHolder(nil) did not fail")
+	}
+
+	first := liveSpec("First")
+	holder, err := ui.NewSpecHolder(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := holder.Store(nil); err == nil {
+		t.Error("Store(nil) did not fail")
+	}
+	if holder.Load() != first {
+		t.Error("Store(nil) replaced the spec")
+	}
+
+	doc, err := holder.SpecDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := liveSpec("Second")
+	if err := holder.Store(second); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := holder.SpecDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holder.Load() != second || changed.ETag == doc.ETag {
+		t.Errorf("after Store, Load() = %v and ETag %s, want the second spec with a new ETag", holder.Load().Info.Title, changed.ETag)
+	}
+
+	if err := holder.Store(liveSpec("Second")); err != nil {
+		t.Fatal(err)
+	}
+	if same, _ := holder.SpecDocument(); same != changed {
+		t.Error("storing an identical spec changed the document served")
+	}
+}
+
+// staticProvider is a provider implemented outside the package
+type staticProvider struct{ doc *ui.SpecDocument }
+
+func (p staticProvider) SpecDocument() (*ui.SpecDocument, error) { return p.doc, nil }
+
+func TestSwaggerJSONProviderHandler(t *testing.T) {
+	doc, err := ui.NewSpecDocument(liveSpec("Users"), time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := ui.SwaggerJSONProviderHandler(staticProvider{doc})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/swagger/openapi.json", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != string(doc.Body) {
+		t.Fatalf("GET = %d %s, want 200 with the spec", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("ETag"); got != doc.ETag {
+		t.Errorf("ETag = %s, want %s", got, doc.ETag)
+	}
+	if got := rec.Header().Get("Last-Modified"); got != "Wed, 01 May 2024 12:00:00 GMT" {
+		t.Errorf("Last-Modified = %s", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/openapi.json", nil)
+	req.Header.Set("If-None-Match", doc.ETag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("conditional GET = %d, want 304", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	ui.SwaggerJSONProviderHandler(ui.SpecFunc(func() (*generator.OpenAPI, error) { return nil, nil })).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/swagger/openapi.json", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("GET of a nil spec = %d, want 500", rec.Code)
+	}
+}
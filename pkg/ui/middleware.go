@@ -6,21 +6,51 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// RegisterSwaggerUI mounts GET /swagger serving the Swagger UI page, and the
+// embedded Swagger UI files it loads below /swagger/assets/
 func RegisterSwaggerUI(r *gin.Engine, path string) {
+	RegisterSwaggerUIWithOptions(r, path, UIOptions{})
+}
+
+// RegisterSwaggerUIWithOptions is RegisterSwaggerUI configured by opts
+func RegisterSwaggerUIWithOptions(r *gin.Engine, path string, opts UIOptions) {
+	page := swaggerUIHTML(path, opts)
 	r.GET("/swagger", func(c *gin.Context) {
-		c.Header("Content-Type", "text/html")
+		c.Header("Cache-Control", "no-cache")
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	})
 
-		// Basic embedded Swagger UI HTML pointing to the JSON file
-		swaggerHTML := `
+	if opts.CDN {
+		return
+	}
+	for _, name := range swaggerUIAssetNames() {
+		r.GET(swaggerUIAssetsPath+name, func(c *gin.Context) {
+			serveAsset(c.Writer, c.Request, name)
+		})
+	}
+}
+
+// swaggerUIHTML returns the Swagger UI page loading the spec served under path,
+// and the Swagger UI files served under path or, with opts.CDN, from unpkg.com
+func swaggerUIHTML(path string, opts UIOptions) string {
+	assets := path + swaggerUIAssetsPath
+	if opts.CDN {
+		assets = "https://unpkg.com/swagger-ui-dist@" + swaggerUIVersion + "/"
+	}
+
+	return `
     <!DOCTYPE html>
     <html>
     <head>
+      <meta charset="UTF-8">
       <title>Swagger UI</title>
-      <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+      <link rel="stylesheet" href="` + assets + `swagger-ui.css">
+      <link rel="icon" type="image/png" href="` + assets + `favicon-32x32.png" sizes="32x32">
+      <link rel="icon" type="image/png" href="` + assets + `favicon-16x16.png" sizes="16x16">
     </head>
     <body>
       <div id="swagger-ui"></div>
-      <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+      <script src="` + assets + `swagger-ui-bundle.js" charset="UTF-8"></script>
       <script>
         const ui = SwaggerUIBundle({
         url: '` + path + `/swagger/openapi.json',
@@ -30,6 +60,4 @@ func RegisterSwaggerUI(r *gin.Engine, path string) {
     </body>
     </html>
     `
-		c.String(http.StatusOK, swaggerHTML)
-	})
 }
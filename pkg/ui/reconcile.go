@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uiHandlerPrefix is the handler name prefix of the routes mounted by this
+// package, which are never documented themselves
+const uiHandlerPrefix = "github.com/georgetjose/openapi3gen/pkg/ui."
+
+// RouteMismatch is a route that is registered but not documented, or documented but not registered
+type RouteMismatch struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Handler string `json:"handler,omitempty"` // Name of the registered handler, from runtime.FuncForPC, or of the documented one
+	Summary string `json:"summary,omitempty"` // Summary of the documented operation
+}
+
+func (m RouteMismatch) String() string {
+	s := strings.ToUpper(m.Method) + " " + m.Path
+	if m.Handler != "" {
+		s += " (" + m.Handler + ")"
+	}
+	return s
+}
+
+// RouteReport lists the differences between the routes of a gin engine and a spec
+type RouteReport struct {
+	Missing  []RouteMismatch `json:"missing"`  // Registered routes without an operation in the spec
+	Orphaned []RouteMismatch `json:"orphaned"` // Operations in the spec without a registered route
+}
+
+// OK reports whether every registered route is documented and every operation registered
+func (r *RouteReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Orphaned) == 0
+}
+
+// Err returns an error describing the report, or nil if it is OK
+func (r *RouteReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+
+	var errs []error
+	for _, m := range r.Missing {
+		errs = append(errs, fmt.Errorf("route %s is registered but not documented", m))
+	}
+	for _, m := range r.Orphaned {
+		errs = append(errs, fmt.Errorf("operation %s is documented but not registered", m))
+	}
+	return errors.Join(errs...)
+}
+
+// ReconcileOptions configures ReconcileRoutes
+type ReconcileOptions struct {
+	// Strict makes ReconcileRoutes return an error when the report is not OK,
+	// e.g. to fail startup or a test
+	Strict bool
+
+	// Ignore lists paths left out of the report, in gin (/users/:id) or
+	// OpenAPI (/users/{id}) syntax. A trailing * ignores every path with that prefix.
+	Ignore []string
+
+	// Routes are the parsed routes the spec was generated from. When set, a
+	// registered route whose path is not documented still matches the
+	// operation documented for the same method on its handler function,
+	// compared by the name runtime.FuncForPC gives it.
+	Routes []parser.RouteDoc
+}
+
+// ReconcileRoutes matches the routes registered on r against the operations of
+// openapi by method and path, logging a warning for every route that is not
+// documented and every operation that is not registered. Path parameters are
+// matched by position, so /users/:id matches /users/{userId}, and routes
+// are matched by handler name when opts.Routes is set. The Swagger routes
+// mounted by this package are ignored.
+func ReconcileRoutes(r *gin.Engine, openapi *generator.OpenAPI, opts ReconcileOptions) (*RouteReport, error) {
+	report := &RouteReport{
+		Missing:  []RouteMismatch{},
+		Orphaned: []RouteMismatch{},
+	}
+
+	documented := make(map[string]RouteMismatch)
+	for path, item := range openapi.Paths {
+		if item == nil || isIgnoredRoute(path, opts.Ignore) {
+			continue
+		}
+		for method, op := range item.Operations() {
+			documented[routeKey(method, path)] = RouteMismatch{Method: method, Path: path, Summary: op.Summary}
+		}
+	}
+
+	// Operation keys by method and handler function
+	byHandler := make(map[string][]string)
+	for _, route := range opts.Routes {
+		methods := []string{strings.ToLower(route.Method)}
+		if methods[0] == "any" {
+			// gin's Any and method-less ServeMux patterns are documented for every method
+			methods = generator.HTTPMethods
+		}
+		for _, method := range methods {
+			key := routeKey(method, ginPathTemplate(route.Path))
+			op, ok := documented[key]
+			if !ok || route.Func == "" {
+				continue
+			}
+			op.Handler = route.Func
+			documented[key] = op
+			handlerKey := method + " " + route.Func
+			byHandler[handlerKey] = append(byHandler[handlerKey], key)
+		}
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		method := strings.ToLower(route.Method)
+		if strings.HasPrefix(route.Handler, uiHandlerPrefix) || isIgnoredRoute(route.Path, opts.Ignore) {
+			continue
+		}
+		if !slices.Contains(generator.HTTPMethods, method) {
+			// gin's Any also registers CONNECT, which OpenAPI cannot document
+			continue
+		}
+
+		key := routeKey(method, ginPathTemplate(route.Path))
+		if _, ok := documented[key]; ok {
+			registered[key] = true
+			continue
+		}
+		// Method values are named with a -fm suffix
+		if keys := byHandler[method+" "+strings.TrimSuffix(route.Handler, "-fm")]; len(keys) > 0 {
+			for _, key := range keys {
+				registered[key] = true
+			}
+			continue
+		}
+		report.Missing = append(report.Missing, RouteMismatch{Method: method, Path: route.Path, Handler: route.Handler})
+	}
+
+	for key, op := range documented {
+		if !registered[key] {
+			report.Orphaned = append(report.Orphaned, op)
+		}
+	}
+
+	sortMismatches(report.Missing)
+	sortMismatches(report.Orphaned)
+
+	for _, m := range report.Missing {
+		log.Printf("Warning: route %s is registered but not documented\n", m)
+	}
+	for _, m := range report.Orphaned {
+		log.Printf("Warning: operation %s is documented but not registered\n", m)
+	}
+
+	if opts.Strict {
+		return report, report.Err()
+	}
+	return report, nil
+}
+
+// RegisterRouteReportHandler mounts GET /swagger/routes.json serving report
+func RegisterRouteReportHandler(r *gin.Engine, report *RouteReport) {
+	r.GET("/swagger/routes.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, report)
+	})
+}
+
+// ginPathTemplate converts gin's :param and *wildcard segments into path templates
+func ginPathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// routeKey identifies a route by method and path, ignoring path parameter names
+func routeKey(method, path string) string {
+	return strings.ToLower(method) + " " + pathKey(path)
+}
+
+// pathKey identifies a path template, ignoring path parameter names
+func pathKey(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIgnoredRoute reports whether path, in gin or OpenAPI syntax, matches one
+// of the ignore patterns, compared as path templates
+func isIgnoredRoute(path string, ignore []string) bool {
+	key := pathKey(ginPathTemplate(path))
+	for _, pattern := range ignore {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(key, pathKey(ginPathTemplate(prefix))) {
+			return true
+		}
+		if key == pathKey(ginPathTemplate(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortMismatches(mismatches []RouteMismatch) {
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Path != mismatches[j].Path {
+			return mismatches[i].Path < mismatches[j].Path
+		}
+		return mismatches[i].Method < mismatches[j].Method
+	})
+}
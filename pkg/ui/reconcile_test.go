@@ -0,0 +1,131 @@
+package ui_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/georgetjose/openapi3gen/pkg/generator"
+	"github.com/georgetjose/openapi3gen/pkg/parser"
+	"github.com/georgetjose/openapi3gen/pkg/ui"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testPkg is the import path runtime.FuncForPC names the handlers of this file with
+const testPkg = "github.com/georgetjose/openapi3gen/pkg/ui_test"
+
+func getUser(c *gin.Context)   {}
+func listUsers(c *gin.Context) {}
+func health(c *gin.Context)    {}
+
+func TestReconcileRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openapi := &generator.OpenAPI{
+		Paths: map[string]*generator.PathItem{
+			"/users/{userId}": {Get: &generator.Operation{Summary: "Get user"}},
+			"/users":          {Get: &generator.Operation{Summary: "List users"}},
+			"/orders/{id}":    {Get: &generator.Operation{Summary: "Get order"}},
+		},
+	}
+	routes := []parser.RouteDoc{
+		{Method: "GET", Path: "/users/{userId}", Handler: "getUser", Func: testPkg + ".getUser"},
+		{Method: "GET", Path: "/users", Handler: "listUsers", Func: testPkg + ".listUsers"},
+	}
+
+	tests := []struct {
+		name         string
+		opts         ui.ReconcileOptions
+		wantMissing  []string
+		wantOrphaned []string
+	}{
+		{
+			name:         "ByPath",
+			wantMissing:  []string{"GET /api/users", "GET /health"},
+			wantOrphaned: []string{"GET /orders/{id}", "GET /users"},
+		},
+		{
+			name:         "IgnoreGinSyntax",
+			opts:         ui.ReconcileOptions{Ignore: []string{"/health", "/orders/:id", "/api/*"}},
+			wantOrphaned: []string{"GET /users"},
+		},
+		{
+			name:         "IgnoreOpenAPISyntax",
+			opts:         ui.ReconcileOptions{Ignore: []string{"/health", "/orders/{orderId}", "/users"}},
+			wantMissing:  []string{"GET /api/users"},
+			wantOrphaned: nil,
+		},
+		{
+			name:         "ByHandler",
+			opts:         ui.ReconcileOptions{Routes: routes, Ignore: []string{"/health"}},
+			wantOrphaned: []string{"GET /orders/{id}"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/users/:id", getUser)
+			r.GET("/api/users", listUsers)
+			r.GET("/health", health)
+
+			report, err := ui.ReconcileRoutes(r, openapi, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := mismatchRoutes(report.Missing); !slices.Equal(got, tt.wantMissing) {
+				t.Errorf("missing = %q, want %q", got, tt.wantMissing)
+			}
+			if got := mismatchRoutes(report.Orphaned); !slices.Equal(got, tt.wantOrphaned) {
+				t.Errorf("orphaned = %q, want %q", got, tt.wantOrphaned)
+			}
+		})
+	}
+}
+
+type usersAPI struct{}
+
+func (usersAPI) list(c *gin.Context) {}
+
+type ordersAPI struct{}
+
+func (*ordersAPI) list(c *gin.Context) {}
+
+func TestReconcileRoutesByHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	status := &generator.Operation{Summary: "Status"}
+	openapi := &generator.OpenAPI{
+		Paths: map[string]*generator.PathItem{
+			"/users":  {Get: &generator.Operation{Summary: "List users"}},
+			"/orders": {Get: &generator.Operation{Summary: "List orders"}},
+			"/health": {Get: status, Put: status, Post: status, Delete: status, Options: status, Head: status, Patch: status, Trace: status},
+		},
+	}
+	routes := []parser.RouteDoc{
+		{Method: "GET", Path: "/users", Handler: "list", Func: testPkg + ".usersAPI.list"},
+		{Method: "GET", Path: "/orders", Handler: "list", Func: testPkg + ".(*ordersAPI).list"},
+		{Method: "any", Path: "/health", Handler: "health", Func: testPkg + ".health"},
+	}
+
+	r := gin.New()
+	// Only the users handler is registered, under another path
+	r.GET("/api/users", usersAPI{}.list)
+	r.Any("/status", health)
+
+	report, err := ui.ReconcileRoutes(r, openapi, ui.ReconcileOptions{Routes: routes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Missing) > 0 {
+		t.Errorf("missing = %v, want none", report.Missing)
+	}
+	if got := mismatchRoutes(report.Orphaned); !slices.Equal(got, []string{"GET /orders"}) {
+		t.Errorf("orphaned = %q, want only the orders route, whose handler has the same name as the users one", got)
+	}
+}
+
+func mismatchRoutes(mismatches []ui.RouteMismatch) []string {
+	var routes []string
+	for _, m := range mismatches {
+		routes = append(routes, "GET "+m.Path)
+	}
+	return routes
+}